@@ -3,25 +3,136 @@ package cli
 import (
 	"bytes"
 	"encoding/json"
+	"flag"
+	"fmt"
 	"log"
+	"strings"
+	"time"
 
 	system "os"
 
 	"github.com/klauspost/compress/gzip"
 	"github.com/klauspost/compress/zstd"
+	"github.com/quickemu-project/quickget_configs/internal/notify"
 	"github.com/quickemu-project/quickget_configs/internal/os"
+	"github.com/quickemu-project/quickget_configs/internal/regression"
 	"github.com/quickemu-project/quickget_configs/internal/utils"
+	"github.com/quickemu-project/quickget_configs/internal/web"
 	"github.com/quickemu-project/quickget_configs/pkg/quickgetdata"
 )
 
+var httpCacheDir = flag.String("http-cache", "", "Directory to cache HTTP responses in between runs. Caching is disabled if empty")
+
+var historyFile = flag.String("history", "", "File to append each run's results to, for showing trends on the status page. History is disabled if empty")
+
+var hostLimits = flag.String("host-limits", "", `Comma-separated per-host request limits in the form "host=concurrency[:requests per second]", overriding the built-in limits`)
+
+var previousData = flag.String("previous", "", "The quickget_data_all.json (optionally .gz or .zst) of a previous run, to compare the new configs with. Regressions aren't checked if empty")
+
+var maxRegressions = flag.Int("max-regressions", -1, "Exit with an error once everything is written if more regressions than this are found. Negative values never fail")
+
+var notifyConfig = flag.String("notify", "", "JSON file listing webhooks to send failures, recoveries, new OSes, new releases and checksum mismatches to at the end of the run. Notifications are disabled if empty")
+
+var notifyTest = flag.Bool("notify-test", false, "Send an example of every notification to the webhooks in the -notify file, then exit without generating configs")
+
+var maxConnections = flag.Int("max-connections", 150, "Maximum number of HTTP requests in flight across all hosts")
+
+var architectures = flag.String("arch", "legacy", `Comma-separated architectures to include in quickget_data.json. "legacy" limits the output to the architectures published before others were supported, and "all" disables filtering`)
+
 func Launch() {
+	flag.Parse()
+	archFilter, err := parseArchFilter(*architectures)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	if err := web.ParseHostLimits(*hostLimits); err != nil {
+		log.Fatalln(err)
+	}
+	web.SetGlobalLimit(*maxConnections)
+
+	var notifications *notify.Config
+	if *notifyConfig != "" {
+		if notifications, err = notify.Load(*notifyConfig); err != nil {
+			log.Fatalln(err)
+		}
+	}
+	if *notifyTest {
+		if notifications == nil {
+			log.Fatalln("-notify-test requires a -notify file")
+		}
+		if err := notifications.Send(time.Now(), notify.SampleEvents()); err != nil {
+			log.Fatalln(err)
+		}
+		return
+	}
+
+	if *httpCacheDir != "" {
+		if err := web.EnableCache(*httpCacheDir); err != nil {
+			log.Printf("Could not enable HTTP cache: %s", err)
+		}
+	}
+
 	distros, status := utils.SpawnDistros(os.List...)
+	status.SetHistoryFile(*historyFile)
+
+	if err := web.PruneCache(); err != nil {
+		log.Printf("Could not prune HTTP cache: %s", err)
+	}
 	distros = fixList(distros)
+	previous := loadPrevious()
+	regressions := regression.Compare(previous, distros)
+	status.SetRegressions(regressions)
+	status.SetThrottling(web.ThrottlingReport())
+	status.SetUsage(web.UsageReport())
+	pages, validations := web.MemoReport()
+	log.Printf("Page requests: %d, %d fetched, %d reused, %d coalesced", pages.Calls, pages.Misses(), pages.Hits, pages.Coalesced)
+	log.Printf("URL validations: %d, %d probed, %d reused, %d coalesced", validations.Calls, validations.Misses(), validations.Hits, validations.Coalesced)
 
 	if err := status.Finalize(); err != nil {
 		log.Printf("Failed to create status webpage: %s", err)
 	}
+	if notifications != nil {
+		events := notify.Detect(previous, distros, status.Report())
+		log.Printf("Sending %d notifications", len(events))
+		if err := notifications.Send(status.StartTime, events); err != nil {
+			log.Println(err)
+		}
+	}
+
+	if archFilter != nil {
+		// Consumers that want every architecture can opt into the unfiltered data without changing the default output
+		writeAll(distros, "quickget_data_all")
+		distros = filterArchitectures(distros, archFilter)
+	}
+	writeAll(distros, "quickget_data")
+
+	enc := json.NewEncoder(system.Stdout)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(distros); err != nil {
+		log.Fatalln(err)
+	}
+
+	if *maxRegressions >= 0 && len(regressions) > *maxRegressions {
+		log.Fatalf("Found %d regressions, more than the %d allowed", len(regressions), *maxRegressions)
+	}
+}
+
+// Reads the previous run's data, if it was given, so the new configs can be compared with it
+func loadPrevious() []utils.OSData {
+	if *previousData == "" {
+		return nil
+	}
+	previous, err := regression.Load(*previousData)
+	if err != nil {
+		log.Printf("Could not load previous data, so regressions and new releases can't be found: %s", err)
+		return nil
+	}
+	return previous
+}
 
+func writeAll(distros []utils.OSData, basename string) {
 	var buf bytes.Buffer
 	enc := json.NewEncoder(&buf)
 	enc.SetEscapeHTML(false)
@@ -31,22 +142,59 @@ func Launch() {
 	}
 	rawJson := buf.Bytes()
 
-	if err := writeData(rawJson, "quickget_data.json", None); err != nil {
+	if err := writeData(rawJson, basename+".json", None); err != nil {
 		log.Printf("Could not write uncompressed JSON: %s", err)
 	}
-	if err := writeData(rawJson, "quickget_data.json.gz", Gzip); err != nil {
+	if err := writeData(rawJson, basename+".json.gz", Gzip); err != nil {
 		log.Printf("Could not write gzip-compressed JSON: %s", err)
 	}
-	if err := writeData(rawJson, "quickget_data.json.zst", Zstd); err != nil {
+	if err := writeData(rawJson, basename+".json.zst", Zstd); err != nil {
 		log.Printf("Could not write zstd-compressed JSON: %s", err)
 	}
+}
 
-	enc = json.NewEncoder(system.Stdout)
-	enc.SetEscapeHTML(false)
-	enc.SetIndent("", "  ")
-	if err := enc.Encode(distros); err != nil {
-		log.Fatalln(err)
+// Returns the set of architectures to include in the output, or nil if every architecture should be included
+func parseArchFilter(input string) (map[quickgetdata.Arch]struct{}, error) {
+	filter := make(map[quickgetdata.Arch]struct{})
+	for _, a := range strings.Split(input, ",") {
+		switch a = strings.TrimSpace(a); a {
+		case "all":
+			return nil, nil
+		case "legacy":
+			for _, arch := range quickgetdata.LegacyArchitectures {
+				filter[arch] = struct{}{}
+			}
+		default:
+			arch, valid := quickgetdata.NewArch(a)
+			if !valid {
+				return nil, fmt.Errorf("Unknown architecture %q", a)
+			}
+			filter[arch] = struct{}{}
+		}
+	}
+	return filter, nil
+}
+
+func filterArchitectures(distros []utils.OSData, filter map[quickgetdata.Arch]struct{}) []utils.OSData {
+	filtered := make([]utils.OSData, 0, len(distros))
+	for _, distro := range distros {
+		releases := make([]quickgetdata.Config, 0, len(distro.Releases))
+		for _, config := range distro.Releases {
+			arch := config.Arch
+			// fixList has already removed the default architecture
+			if arch == "" {
+				arch = quickgetdata.X86_64
+			}
+			if _, ok := filter[arch]; ok {
+				releases = append(releases, config)
+			}
+		}
+		if len(releases) > 0 {
+			distro.Releases = releases
+			filtered = append(filtered, distro)
+		}
 	}
+	return filtered
 }
 
 func fixList(distros []utils.OSData) []utils.OSData {
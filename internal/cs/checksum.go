@@ -1,28 +1,29 @@
 package cs
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"net/url"
 	"path"
 	"regexp"
 	"strings"
 
+	"github.com/quickemu-project/quickget_configs/internal/data"
 	"github.com/quickemu-project/quickget_configs/internal/mirror"
 	"github.com/quickemu-project/quickget_configs/internal/web"
 )
 
-func SingleWhitespace[T string | *url.URL | mirror.File](input T) (string, error) {
+func SingleWhitespace[T string | *url.URL | mirror.File](ctx context.Context, input T) (string, error) {
 	var data string
 	var err error
 
 	switch v := any(input).(type) {
 	case string:
-		data, err = web.CapturePage(v)
+		data, err = web.CapturePage(ctx, v)
 	case *url.URL:
-		data, err = web.CapturePage(v)
+		data, err = web.CapturePage(ctx, v)
 	case mirror.File:
-		data, err = web.CapturePage(v.URL)
+		data, err = web.CapturePage(ctx, v.URL)
 	}
 	if err != nil {
 		return "", fmt.Errorf("Failed to find single checksum: %w", err)
@@ -30,12 +31,30 @@ func SingleWhitespace[T string | *url.URL | mirror.File](input T) (string, error
 	return BuildSingleWhitespace(data)
 }
 
-func BuildSingleWhitespace(data string) (string, error) {
-	index := strings.Index(data, " ")
+func BuildSingleWhitespace(page string) (string, error) {
+	index := strings.Index(page, " ")
 	if index == -1 {
-		return "", errors.New("No whitespace was present in the checksum data")
+		return "", data.Errorf(data.Parse, "No whitespace was present in the checksum data")
 	}
-	return data[:index], nil
+	return page[:index], nil
+}
+
+// Returns the checksum of a file from a checksum map, or a ChecksumMissing error if it isn't listed
+func Lookup(checksums map[string]string, filename string) (string, error) {
+	checksum, ok := checksums[filename]
+	if !ok {
+		return "", data.Errorf(data.ChecksumMissing, "No checksum was published for %s", filename)
+	}
+	return checksum, nil
+}
+
+// Compares a checksum published alongside a file with one recorded elsewhere, such as by a forge.
+// Returns a ChecksumMismatch error if both are known and differ
+func Verify(published, recorded string) error {
+	if published == "" || recorded == "" || strings.EqualFold(published, recorded) {
+		return nil
+	}
+	return data.Errorf(data.ChecksumMismatch, "Published checksum %s does not match recorded checksum %s", published, recorded)
 }
 
 type ChecksumSeparation interface {
@@ -44,17 +63,17 @@ type ChecksumSeparation interface {
 
 // Builds a checksum map from the contents of a URL and a pattern. Errors when the URL cannot be resolved.
 // Return map is guaranteed to always be valid, even in the case of an error
-func Build[T string | *url.URL | mirror.File](cs ChecksumSeparation, input T) (map[string]string, error) {
+func Build[T string | *url.URL | mirror.File](ctx context.Context, cs ChecksumSeparation, input T) (map[string]string, error) {
 	var data string
 	var err error
 
 	switch v := any(input).(type) {
 	case string:
-		data, err = web.CapturePage(v)
+		data, err = web.CapturePage(ctx, v)
 	case *url.URL:
-		data, err = web.CapturePage(v)
+		data, err = web.CapturePage(ctx, v)
 	case mirror.File:
-		data, err = web.CapturePage(v.URL)
+		data, err = web.CapturePage(ctx, v.URL)
 	}
 	if err != nil {
 		return make(map[string]string), fmt.Errorf("Failed to build checksums: %w", err)
@@ -1,13 +1,71 @@
 package data
 
+import "time"
+
 type GithubAPI struct {
-	TagName    string        `json:"tag_name"`
-	Assets     []GithubAsset `json:"assets"`
-	Prerelease bool          `json:"prerelease"`
-	Body       string        `json:"body"`
+	TagName     string        `json:"tag_name"`
+	Name        string        `json:"name"`
+	Assets      []GithubAsset `json:"assets"`
+	Draft       bool          `json:"draft"`
+	Prerelease  bool          `json:"prerelease"`
+	PublishedAt time.Time     `json:"published_at"`
+	Body        string        `json:"body"`
 }
 
 type GithubAsset struct {
 	Name string `json:"name"`
 	URL  string `json:"browser_download_url"`
+	Size int64  `json:"size"`
+	// In the form "sha256:<hex>". Only present for assets uploaded since GitHub began recording digests
+	Digest string `json:"digest"`
+}
+
+type GitlabAPI struct {
+	TagName         string       `json:"tag_name"`
+	Name            string       `json:"name"`
+	Description     string       `json:"description"`
+	UpcomingRelease bool         `json:"upcoming_release"`
+	ReleasedAt      time.Time    `json:"released_at"`
+	Assets          GitlabAssets `json:"assets"`
+}
+
+type GitlabAssets struct {
+	Links []GitlabLink `json:"links"`
+}
+
+type GitlabLink struct {
+	Name           string `json:"name"`
+	URL            string `json:"url"`
+	DirectAssetURL string `json:"direct_asset_url"`
+}
+
+type GiteaAPI struct {
+	TagName     string       `json:"tag_name"`
+	Name        string       `json:"name"`
+	Body        string       `json:"body"`
+	Draft       bool         `json:"draft"`
+	Prerelease  bool         `json:"prerelease"`
+	PublishedAt time.Time    `json:"published_at"`
+	Assets      []GiteaAsset `json:"assets"`
+}
+
+type GiteaAsset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+	Size int64  `json:"size"`
+}
+
+// A page of results from the S3 ListObjectsV2 API
+type S3ListBucketResult struct {
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+	Contents              []struct {
+		Key          string    `xml:"Key"`
+		LastModified time.Time `xml:"LastModified"`
+		ETag         string    `xml:"ETag"`
+		Size         int64     `xml:"Size"`
+	} `xml:"Contents"`
+	CommonPrefixes []struct {
+		Prefix string `xml:"Prefix"`
+	} `xml:"CommonPrefixes"`
 }
@@ -1,6 +1,15 @@
 package data
 
-import "github.com/quickemu-project/quickget_configs/pkg/quickgetdata"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+
+	"github.com/quickemu-project/quickget_configs/pkg/quickgetdata"
+)
 
 type Failure struct {
 	Release string
@@ -8,3 +17,94 @@ type Failure struct {
 	Arch    quickgetdata.Arch
 	Error   error
 }
+
+// Returns the kind of the failure, and the host involved if known
+func (f Failure) Kind() (FailureKind, string) {
+	return Classify(f.Error)
+}
+
+// The cause of a failure, so that reports can group failures instead of only printing their messages
+type FailureKind string
+
+const (
+	// A server responded with an unexpected HTTP status
+	HTTPStatus FailureKind = "http_status"
+	// A connection failed or timed out
+	Network FailureKind = "network"
+	// A page or file couldn't be parsed, or didn't contain what was expected
+	Parse FailureKind = "parse"
+	// No checksum was published for a file which should have one
+	ChecksumMissing FailureKind = "checksum_missing"
+	// Two published checksums for the same file disagree
+	ChecksumMismatch FailureKind = "checksum_mismatch"
+	// A URL in a config was rejected when validating it
+	ValidationRejected FailureKind = "validation_rejected"
+	// A config function panicked
+	Panic FailureKind = "panic"
+	// Any other error
+	Unknown FailureKind = "unknown"
+)
+
+// Every failure kind, in the order they're reported
+var FailureKinds = []FailureKind{HTTPStatus, Network, Parse, ChecksumMissing, ChecksumMismatch, ValidationRejected, Panic, Unknown}
+
+// Implemented by errors which know their failure kind, and the host involved if any
+type KindedError interface {
+	error
+	FailureKind() (FailureKind, string)
+}
+
+// An error annotated with its failure kind
+type KindError struct {
+	Kind FailureKind
+	// The host the failure relates to, or empty if there isn't one
+	Host string
+	Err  error
+}
+
+func (e *KindError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *KindError) Unwrap() error {
+	return e.Err
+}
+
+func (e *KindError) FailureKind() (FailureKind, string) {
+	return e.Kind, e.Host
+}
+
+// Creates an error of the given kind, formatted as with fmt.Errorf
+func Errorf(kind FailureKind, format string, a ...any) error {
+	return &KindError{Kind: kind, Err: fmt.Errorf(format, a...)}
+}
+
+// Determines the kind of an error, and the host involved if known. Errors which don't report a kind are classified as
+// network failures if they come from a connection, and otherwise as Unknown
+func Classify(err error) (FailureKind, string) {
+	if err == nil {
+		return "", ""
+	}
+	var host string
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		if u, parseErr := url.Parse(urlErr.URL); parseErr == nil {
+			host = u.Hostname()
+		}
+	}
+
+	var kinded KindedError
+	if errors.As(err, &kinded) {
+		kind, kindHost := kinded.FailureKind()
+		if kindHost != "" {
+			host = kindHost
+		}
+		return kind, host
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) || urlErr != nil || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, os.ErrDeadlineExceeded) {
+		return Network, host
+	}
+	return Unknown, host
+}
@@ -0,0 +1,33 @@
+package data
+
+import "github.com/quickemu-project/quickget_configs/pkg/quickgetdata"
+
+// How an OS's configs got worse since the previous run
+type RegressionKind string
+
+const (
+	// The OS has fewer releases than before
+	LostReleases RegressionKind = "lost_releases"
+	// No config of the OS has an architecture which one had before
+	LostArch RegressionKind = "lost_arch"
+	// No config of the OS has an edition which one had before
+	LostEdition RegressionKind = "lost_edition"
+	// A config has fewer sources with checksums than before
+	LostChecksum RegressionKind = "lost_checksum"
+	// A config's sources are served by different hosts than before
+	HostChanged RegressionKind = "host_changed"
+)
+
+// Every regression kind, in the order they're reported
+var RegressionKinds = []RegressionKind{LostReleases, LostArch, LostEdition, LostChecksum, HostChanged}
+
+// A difference from the previous run which suggests an OS's configs are no longer being created correctly
+type Regression struct {
+	OS   string
+	Kind RegressionKind
+	// The config affected. Empty for regressions which affect the whole OS
+	Release string
+	Edition string
+	Arch    quickgetdata.Arch
+	Message string
+}
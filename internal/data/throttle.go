@@ -0,0 +1,19 @@
+package data
+
+import "time"
+
+// Per-host request statistics, reported on the status page for hosts which throttled or failed requests
+type HostThrottling struct {
+	Host     string
+	Requests int
+	// Responses with HTTP 429 or 503, including those later retried successfully
+	Throttled int
+	// Requests which ultimately failed with a network error, 429 or a server error
+	Failures int
+	// The number of times the allowed concurrency was lowered
+	BackOffs int
+	// The lowest concurrency the host was limited to during the run
+	MinConcurrency int
+	// Total time requests spent waiting for the request rate or a Retry-After pause
+	Waited time.Duration
+}
@@ -0,0 +1,55 @@
+package data
+
+import "time"
+
+// Requests made on behalf of an OS during one phase of its run
+type NetworkUsage struct {
+	Requests int
+	// Attempts repeated after a network error, throttling or a server error. Not included in Requests
+	Retries int
+	// Bytes of response bodies read
+	Bytes int64
+}
+
+func (u NetworkUsage) Add(other NetworkUsage) NetworkUsage {
+	return NetworkUsage{
+		Requests: u.Requests + other.Requests,
+		Retries:  u.Retries + other.Retries,
+		Bytes:    u.Bytes + other.Bytes,
+	}
+}
+
+// The requests made on behalf of an OS, split between creating its configs and validating them
+type OSUsage struct {
+	Scrape     NetworkUsage
+	Validation NetworkUsage
+}
+
+func (u OSUsage) Total() NetworkUsage {
+	return u.Scrape.Add(u.Validation)
+}
+
+// Per-host response times. A request's time runs from sending it to receiving the response headers, including any retries
+type HostLatency struct {
+	Host     string
+	Requests int
+	Total    time.Duration
+	Max      time.Duration
+}
+
+func (l HostLatency) Mean() time.Duration {
+	if l.Requests == 0 {
+		return 0
+	}
+	return l.Total / time.Duration(l.Requests)
+}
+
+// The time an OS spent creating its configs and validating them
+type OSTiming struct {
+	Scrape     time.Duration
+	Validation time.Duration
+}
+
+func (t OSTiming) Total() time.Duration {
+	return t.Scrape + t.Validation
+}
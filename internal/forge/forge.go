@@ -0,0 +1,61 @@
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"net/http"
+	"regexp"
+
+	"github.com/quickemu-project/quickget_configs/internal/data"
+	"github.com/quickemu-project/quickget_configs/internal/web"
+)
+
+// A code forge hosting project releases
+type Forge interface {
+	// Returns the published releases of a project, newest first. Pages are only fetched as they are needed,
+	// so callers can stop early. Drafts are skipped
+	Releases(ctx context.Context, project string) iter.Seq2[Release, error]
+}
+
+var nextLinkRe = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// Follows Link headers through a paginated list of releases, converting each entry with convert
+func paginate[T any](ctx context.Context, first string, request func(ctx context.Context, url string, v any) (http.Header, error), convert func(T) (Release, bool)) iter.Seq2[Release, error] {
+	return func(yield func(Release, error) bool) {
+		next := first
+		for next != "" {
+			var page []T
+			header, err := request(ctx, next, &page)
+			if err != nil {
+				yield(Release{}, err)
+				return
+			}
+			for _, entry := range page {
+				release, ok := convert(entry)
+				if !ok {
+					continue
+				}
+				if !yield(release, nil) {
+					return
+				}
+			}
+			next = ""
+			if match := nextLinkRe.FindStringSubmatch(header.Get("Link")); match != nil {
+				next = match[1]
+			}
+		}
+	}
+}
+
+func jsonRequest(ctx context.Context, url string, headers http.Header, v any) (http.Header, error) {
+	resp, err := web.GetResponse(ctx, url, headers)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return nil, data.Errorf(data.Parse, "Failed to decode release data from %s: %w", url, err)
+	}
+	return resp.Header, nil
+}
@@ -0,0 +1,46 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+	"strings"
+
+	"github.com/quickemu-project/quickget_configs/internal/data"
+)
+
+// A Gitea or Forgejo instance, e.g. "https://codeberg.org"
+type Gitea string
+
+// Codeberg, the largest public Forgejo instance
+const Codeberg = Gitea("https://codeberg.org")
+
+// Returns the releases of a repository in the form "owner/name"
+func (g Gitea) Releases(ctx context.Context, repo string) iter.Seq2[Release, error] {
+	first := fmt.Sprintf("%s/api/v1/repos/%s/releases?limit=50", strings.TrimSuffix(string(g), "/"), repo)
+	return paginate(ctx, first, giteaRequest, giteaRelease)
+}
+
+func giteaRequest(ctx context.Context, url string, v any) (http.Header, error) {
+	return jsonRequest(ctx, url, http.Header{"Accept": []string{"application/json"}}, v)
+}
+
+func giteaRelease(release data.GiteaAPI) (Release, bool) {
+	assets := make([]Asset, 0, len(release.Assets))
+	for _, asset := range release.Assets {
+		assets = append(assets, Asset{
+			Name: asset.Name,
+			URL:  asset.URL,
+			Size: asset.Size,
+		})
+	}
+	return Release{
+		Tag:         release.TagName,
+		Name:        release.Name,
+		Body:        release.Body,
+		Prerelease:  release.Prerelease,
+		PublishedAt: release.PublishedAt,
+		Assets:      assets,
+	}, !release.Draft
+}
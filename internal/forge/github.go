@@ -0,0 +1,137 @@
+package forge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/quickemu-project/quickget_configs/internal/data"
+	"github.com/quickemu-project/quickget_configs/internal/web"
+)
+
+const (
+	githubAPI = "https://api.github.com/"
+	// Rate limits resetting further in the future than this fail instead of stalling the run
+	maxRateLimitWait = 2 * time.Minute
+)
+
+// Tracks the API rate limit shared by every request made with the same credentials
+var githubRateLimit struct {
+	sync.Mutex
+	exhausted bool
+	reset     time.Time
+}
+
+// The GitHub API. A token is read from GITHUB_TOKEN or GH_TOKEN if either is set
+var GitHub Forge = github{}
+
+type github struct{}
+
+// Returns the releases of a repository in the form "owner/name"
+func (github) Releases(ctx context.Context, repo string) iter.Seq2[Release, error] {
+	first := fmt.Sprintf("%srepos/%s/releases?per_page=100", githubAPI, repo)
+	return paginate(ctx, first, githubRequest, githubRelease)
+}
+
+func githubRelease(release data.GithubAPI) (Release, bool) {
+	assets := make([]Asset, 0, len(release.Assets))
+	for _, asset := range release.Assets {
+		assets = append(assets, Asset{
+			Name:   asset.Name,
+			URL:    asset.URL,
+			Size:   asset.Size,
+			Digest: asset.Digest,
+		})
+	}
+	return Release{
+		Tag:         release.TagName,
+		Name:        release.Name,
+		Body:        release.Body,
+		Prerelease:  release.Prerelease,
+		PublishedAt: release.PublishedAt,
+		Assets:      assets,
+	}, !release.Draft
+}
+
+func githubHeaders() http.Header {
+	headers := http.Header{
+		"Accept":               []string{"application/vnd.github+json"},
+		"X-Github-Api-Version": []string{"2022-11-28"},
+	}
+	for _, env := range []string{"GITHUB_TOKEN", "GH_TOKEN"} {
+		if token := os.Getenv(env); token != "" {
+			headers.Set("Authorization", "Bearer "+token)
+			break
+		}
+	}
+	return headers
+}
+
+// Makes an API request, waiting for the rate limit to reset if it will do so soon
+func githubRequest(ctx context.Context, url string, v any) (http.Header, error) {
+	for retried := false; ; retried = true {
+		if err := waitForGithubRateLimit(); err != nil {
+			return nil, err
+		}
+		header, err := jsonRequest(ctx, url, githubHeaders(), v)
+		var statusErr *web.StatusError
+		if errors.As(err, &statusErr) && updateGithubRateLimit(statusErr.StatusCode, statusErr.Header) && !retried {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		updateGithubRateLimit(http.StatusOK, header)
+		return header, nil
+	}
+}
+
+func waitForGithubRateLimit() error {
+	githubRateLimit.Lock()
+	exhausted, reset := githubRateLimit.exhausted, githubRateLimit.reset
+	githubRateLimit.Unlock()
+	if !exhausted {
+		return nil
+	}
+	wait := time.Until(reset)
+	if wait > maxRateLimitWait {
+		return &data.KindError{
+			Kind: data.HTTPStatus,
+			Host: "api.github.com",
+			Err:  fmt.Errorf("GitHub API rate limit exhausted until %s. Set GITHUB_TOKEN to raise the limit", reset.Format(time.TimeOnly)),
+		}
+	}
+	if wait > 0 {
+		log.Printf("Waiting %s for the GitHub API rate limit to reset", wait.Round(time.Second))
+		time.Sleep(wait)
+	}
+	githubRateLimit.Lock()
+	if githubRateLimit.reset.Equal(reset) {
+		githubRateLimit.exhausted = false
+	}
+	githubRateLimit.Unlock()
+	return nil
+}
+
+// Records the rate limit reported by a response, returning whether the request was rejected by it and should be retried
+func updateGithubRateLimit(status int, header http.Header) bool {
+	remaining, err := strconv.Atoi(header.Get("X-Ratelimit-Remaining"))
+	if err != nil || remaining > 0 {
+		return false
+	}
+	reset := time.Now().Add(time.Minute)
+	if epoch, err := strconv.ParseInt(header.Get("X-Ratelimit-Reset"), 10, 64); err == nil {
+		reset = time.Unix(epoch, 0)
+	}
+	githubRateLimit.Lock()
+	githubRateLimit.exhausted = true
+	githubRateLimit.reset = reset
+	githubRateLimit.Unlock()
+	return status == http.StatusForbidden || status == http.StatusTooManyRequests
+}
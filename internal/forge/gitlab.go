@@ -0,0 +1,49 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/quickemu-project/quickget_configs/internal/data"
+)
+
+// A GitLab instance, e.g. "https://gitlab.com"
+type GitLab string
+
+// Returns the releases of a project, given either its numeric ID or its path in the form "namespace/name"
+func (g GitLab) Releases(ctx context.Context, project string) iter.Seq2[Release, error] {
+	first := fmt.Sprintf("%s/api/v4/projects/%s/releases?per_page=100", strings.TrimSuffix(string(g), "/"), url.PathEscape(project))
+	return paginate(ctx, first, gitlabRequest, gitlabRelease)
+}
+
+func gitlabRequest(ctx context.Context, url string, v any) (http.Header, error) {
+	return jsonRequest(ctx, url, nil, v)
+}
+
+func gitlabRelease(release data.GitlabAPI) (Release, bool) {
+	assets := make([]Asset, 0, len(release.Assets.Links))
+	for _, link := range release.Assets.Links {
+		// Direct asset URLs are permanent, while link URLs may point at expiring job artifacts
+		assetUrl := link.DirectAssetURL
+		if assetUrl == "" {
+			assetUrl = link.URL
+		}
+		assets = append(assets, Asset{
+			Name: link.Name,
+			URL:  assetUrl,
+		})
+	}
+	return Release{
+		Tag:  release.TagName,
+		Name: release.Name,
+		Body: release.Description,
+		// GitLab has no prerelease flag, but releases can be scheduled ahead of time
+		Prerelease:  release.UpcomingRelease,
+		PublishedAt: release.ReleasedAt,
+		Assets:      assets,
+	}, true
+}
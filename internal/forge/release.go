@@ -0,0 +1,42 @@
+package forge
+
+import (
+	"strings"
+	"time"
+)
+
+type Release struct {
+	Tag         string
+	Name        string
+	Body        string
+	Prerelease  bool
+	PublishedAt time.Time
+	Assets      []Asset
+}
+
+type Asset struct {
+	Name string
+	// The download URL of the asset
+	URL  string
+	Size int64
+	// The digest recorded by the forge, in the form "<algorithm>:<hex>". Empty if unknown
+	Digest string
+}
+
+// Returns the SHA256 checksum of the asset recorded by the forge, or an empty string if there is none
+func (a Asset) Sha256() string {
+	if checksum, found := strings.CutPrefix(a.Digest, "sha256:"); found {
+		return checksum
+	}
+	return ""
+}
+
+// Returns the first asset whose name matches, or nil if there is none
+func (r Release) FindAsset(match func(name string) bool) *Asset {
+	for i := range r.Assets {
+		if match(r.Assets[i].Name) {
+			return &r.Assets[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package mirror
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/quickemu-project/quickget_configs/internal/data"
+	"github.com/quickemu-project/quickget_configs/internal/web"
+)
+
+// Detects the format of each directory index it reads, so providers don't need to know which server software a mirror runs.
+// Returns an error when the format isn't recognised, instead of an empty directory
+type AutoClient struct{}
+
+// Servers which can render indexes as JSON (such as Caddy) are asked to, since it's unambiguous
+var autoClientHeaders = http.Header{"Accept": []string{"application/json, text/html;q=0.9, */*;q=0.8"}}
+
+func (c AutoClient) ReadDir(ctx context.Context, urlStr string) (*Directory, error) {
+	u, err := url.Parse(urlStr)
+	if err != nil {
+		return nil, err
+	}
+	return c.ReadDirFromUrl(ctx, u)
+}
+
+func (c AutoClient) ReadDirFromUrl(ctx context.Context, u *url.URL) (*Directory, error) {
+	if u.Scheme == "ftp" {
+		return FTPClient{}.ReadDirFromUrl(ctx, u)
+	}
+	if u.Hostname() == "sourceforge.net" {
+		return SourceForgeClient{}.ReadDirFromUrl(ctx, u)
+	}
+
+	res, err := web.GetResponse(ctx, u, autoClientHeaders)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	contentType := res.Header.Get("Content-Type")
+	trimmed := bytes.TrimSpace(body)
+	var dir *Directory
+	switch {
+	case strings.Contains(contentType, "json") || bytes.HasPrefix(trimmed, []byte("[")):
+		dir, err = c.parseJSON(u, trimmed)
+	case strings.Contains(contentType, "xml") && !strings.Contains(contentType, "html"), bytes.HasPrefix(trimmed, []byte("<?xml")):
+		dir, err = c.parseXML(ctx, u, trimmed)
+	default:
+		dir, err = c.parseHTML(u, body)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read directory index %s: %w", u, err)
+	}
+	return dir, nil
+}
+
+// An entry of a JSON index. nginx (autoindex_format json) and Caddy (browse) use different field names, so this has both
+type jsonIndexEntry struct {
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+	// nginx: "file" or "directory", with an RFC 1123 mtime
+	Type  string `json:"type"`
+	MTime string `json:"mtime"`
+	// Caddy: is_dir, with an RFC 3339 mod_time
+	IsDir   bool      `json:"is_dir"`
+	ModTime time.Time `json:"mod_time"`
+}
+
+func (c AutoClient) parseJSON(u *url.URL, body []byte) (*Directory, error) {
+	var entries []jsonIndexEntry
+	if err := json.Unmarshal(body, &entries); err != nil {
+		return nil, data.Errorf(data.Parse, "unrecognised JSON index: %w", err)
+	}
+	dir := c.newDirectory(u)
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name, "/")
+		modified := entry.ModTime
+		if entry.MTime != "" {
+			modified, _ = time.Parse(time.RFC1123, entry.MTime)
+		}
+		c.addEntry(dir, u, name, entry.IsDir || entry.Type == "directory", entry.Size, modified)
+	}
+	return dir, nil
+}
+
+// The index produced by nginx with autoindex_format xml
+type xmlIndex struct {
+	XMLName xml.Name `xml:"list"`
+	Entries []struct {
+		XMLName xml.Name
+		Name    string    `xml:",chardata"`
+		MTime   time.Time `xml:"mtime,attr"`
+		Size    int64     `xml:"size,attr"`
+	} `xml:",any"`
+}
+
+func (c AutoClient) parseXML(ctx context.Context, u *url.URL, body []byte) (*Directory, error) {
+	var root struct {
+		XMLName xml.Name
+	}
+	if err := xml.Unmarshal(body, &root); err != nil {
+		return nil, err
+	}
+	switch root.XMLName.Local {
+	case "list":
+		var index xmlIndex
+		if err := xml.Unmarshal(body, &index); err != nil {
+			return nil, err
+		}
+		dir := c.newDirectory(u)
+		for _, entry := range index.Entries {
+			c.addEntry(dir, u, entry.Name, entry.XMLName.Local == "directory", entry.Size, entry.MTime)
+		}
+		return dir, nil
+	case "ListBucketResult":
+		// The root of an S3-compatible bucket (e.g. Cloudflare R2). Hand over to the S3 client, which lists by prefix and pages through results
+		return S3Client{BucketPath: u.Path}.ReadDirFromUrl(ctx, u)
+	default:
+		return nil, data.Errorf(data.Parse, "unrecognised XML index with root element %q", root.XMLName.Local)
+	}
+}
+
+func (c AutoClient) parseHTML(u *url.URL, body []byte) (*Directory, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	// h5ai renders its index with JavaScript, but includes a plain table for clients without it
+	if fallback := doc.Find("#fallback"); fallback.Length() > 0 {
+		return parseTableListing(c, u, fallback), nil
+	}
+	// Apache's FancyIndexing with HTMLTable, lighttpd's mod_dirlisting, Caddy's HTML browse template, and most generated indexes
+	if dir := parseTableListing(c, u, doc.Selection); len(dir.Files)+len(dir.SubDirs) > 0 {
+		return dir, nil
+	}
+	// Apache's default listing and nginx autoindex
+	if doc.Find("pre").Length() > 0 {
+		if dir, err := parsePreListing(c, u, doc); err == nil && len(dir.Files)+len(dir.SubDirs) > 0 {
+			return dir, nil
+		}
+	}
+	// An empty directory still has a recognisable title
+	if title := strings.TrimSpace(doc.Find("title").Text()); strings.HasPrefix(title, "Index of") || strings.HasPrefix(title, "Directory listing") {
+		return c.newDirectory(u), nil
+	}
+	return nil, data.Errorf(data.Parse, "unrecognised HTML index")
+}
+
+func (c AutoClient) newDirectory(u *url.URL) *Directory {
+	return &Directory{
+		Name:    path.Base(u.Path),
+		URL:     u,
+		Files:   make(map[string]File),
+		SubDirs: make(map[string]SubDirEntry),
+	}
+}
+
+func (c AutoClient) addEntry(dir *Directory, u *url.URL, name string, isDir bool, size int64, modified time.Time) {
+	if name == "" || name == "." || name == ".." {
+		return
+	}
+	if isDir {
+		dir.SubDirs[name] = SubDirEntry{
+			client:           c,
+			Name:             name,
+			URL:              u.JoinPath(name + "/"),
+			LastModifiedDate: modified,
+		}
+	} else {
+		dir.Files[name] = File{
+			Name:             name,
+			URL:              u.JoinPath(name),
+			LastModifiedDate: modified,
+			FileSize:         size,
+		}
+	}
+}
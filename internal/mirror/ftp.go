@@ -0,0 +1,63 @@
+package mirror
+
+import (
+	"context"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/quickemu-project/quickget_configs/internal/web"
+)
+
+// Reads directories from FTP servers, logging in anonymously unless the URL has credentials
+type FTPClient struct{}
+
+func (c FTPClient) ReadDir(ctx context.Context, urlStr string) (*Directory, error) {
+	u, err := url.Parse(urlStr)
+	if err != nil {
+		return nil, err
+	}
+	return c.ReadDirFromUrl(ctx, u)
+}
+
+func (c FTPClient) ReadDirFromUrl(ctx context.Context, u *url.URL) (*Directory, error) {
+	name := path.Base(u.Path)
+
+	entries, err := web.ReadFTPDir(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+
+	// Subdirectory URLs are only joined correctly from a path with a trailing slash
+	base := *u
+	if !strings.HasSuffix(base.Path, "/") {
+		base.Path += "/"
+	}
+
+	files := make(map[string]File)
+	subdirs := make(map[string]SubDirEntry)
+	for _, entry := range entries {
+		if entry.IsDir {
+			subdirs[entry.Name] = SubDirEntry{
+				client:           c,
+				Name:             entry.Name,
+				URL:              base.JoinPath(entry.Name + "/"),
+				LastModifiedDate: entry.ModTime,
+			}
+		} else {
+			files[entry.Name] = File{
+				Name:             entry.Name,
+				URL:              base.JoinPath(entry.Name),
+				LastModifiedDate: entry.ModTime,
+				FileSize:         entry.Size,
+			}
+		}
+	}
+
+	return &Directory{
+		Name:    name,
+		URL:     u,
+		Files:   files,
+		SubDirs: subdirs,
+	}, nil
+}
@@ -1,6 +1,7 @@
 package mirror
 
 import (
+	"context"
 	"net/url"
 	"path"
 	"regexp"
@@ -41,6 +42,7 @@ var (
 		"02-Jan-2006 15:04",
 		"2006-01-02 15:04",
 		"2006-Jan-02 15:04",
+		"2006-Jan-02 15:04:05",
 	}
 
 	units = map[string]float64{
@@ -107,27 +109,25 @@ func parseFileSize(value string) (int64, error) {
 
 type LegacyHttpClient struct{}
 
-func (c LegacyHttpClient) ReadDir(urlStr string) (*Directory, error) {
+func (c LegacyHttpClient) ReadDir(ctx context.Context, urlStr string) (*Directory, error) {
 	u, err := url.Parse(urlStr)
 	if err != nil {
 		return nil, err
 	}
-	return c.ReadDirFromUrl(u)
+	return c.ReadDirFromUrl(ctx, u)
 }
 
-func (c LegacyHttpClient) ReadDirFromUrl(u *url.URL) (*Directory, error) {
-	name := path.Base(u.Path)
-
-	res, err := web.GetResponse(u, nil)
+func (c LegacyHttpClient) ReadDirFromUrl(ctx context.Context, u *url.URL) (*Directory, error) {
+	doc, err := getDocument(ctx, u)
 	if err != nil {
 		return nil, err
 	}
-	defer res.Body.Close()
+	return parsePreListing(c, u, doc)
+}
 
-	doc, err := goquery.NewDocumentFromReader(res.Body)
-	if err != nil {
-		return nil, err
-	}
+// Parses an index rendered as preformatted text, as produced by Apache (without FancyIndexing tables) and nginx
+func parsePreListing(c Client, u *url.URL, doc *goquery.Document) (*Directory, error) {
+	name := path.Base(u.Path)
 	text, err := doc.Find("pre").First().Html()
 	if err != nil {
 		return nil, err
@@ -183,28 +183,25 @@ func (c LegacyHttpClient) ReadDirFromUrl(u *url.URL) (*Directory, error) {
 
 type HttpClient struct{}
 
-func (c HttpClient) ReadDir(urlStr string) (*Directory, error) {
+func (c HttpClient) ReadDir(ctx context.Context, urlStr string) (*Directory, error) {
 	u, err := url.Parse(urlStr)
 	if err != nil {
 		return nil, err
 	}
-	return c.ReadDirFromUrl(u)
+	return c.ReadDirFromUrl(ctx, u)
 }
 
-func (c HttpClient) ReadDirFromUrl(u *url.URL) (*Directory, error) {
-	name := path.Base(u.Path)
-
-	res, err := web.GetResponse(u, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	doc, err := goquery.NewDocumentFromReader(res.Body)
+func (c HttpClient) ReadDirFromUrl(ctx context.Context, u *url.URL) (*Directory, error) {
+	doc, err := getDocument(ctx, u)
 	if err != nil {
 		return nil, err
 	}
+	return parseTableListing(c, u, doc.Selection), nil
+}
 
+// Parses an index rendered as a table, identifying columns by their classes or contents
+func parseTableListing(c Client, u *url.URL, doc *goquery.Selection) *Directory {
+	name := path.Base(u.Path)
 	files := make(map[string]File)
 	subdirs := make(map[string]SubDirEntry)
 
@@ -270,11 +267,19 @@ func (c HttpClient) ReadDirFromUrl(u *url.URL) (*Directory, error) {
 			}
 		})
 
-		if len(name) == 0 || len(link) == 0 || link == "../" {
+		if len(name) == 0 || len(link) == 0 || link == "../" || link == ".." {
 			return
 		}
 
 		url := u.JoinPath(link)
+		// Some indexes (such as h5ai) use absolute paths, including for the parent directory
+		if strings.HasPrefix(link, "/") {
+			resolved, err := u.Parse(link)
+			if err != nil || !strings.HasPrefix(resolved.Path, strings.TrimSuffix(u.Path, "/")+"/") {
+				return
+			}
+			url = resolved
+		}
 
 		if strings.HasSuffix(link, "/") {
 			name = strings.TrimSuffix(name, "/")
@@ -299,5 +304,14 @@ func (c HttpClient) ReadDirFromUrl(u *url.URL) (*Directory, error) {
 		URL:     u,
 		Files:   files,
 		SubDirs: subdirs,
-	}, nil
+	}
+}
+
+func getDocument(ctx context.Context, u *url.URL) (*goquery.Document, error) {
+	res, err := web.GetResponse(ctx, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	return goquery.NewDocumentFromReader(res.Body)
 }
@@ -1,6 +1,7 @@
 package mirror
 
 import (
+	"context"
 	"iter"
 	"maps"
 	"net/url"
@@ -138,8 +139,8 @@ type SubDirEntry struct {
 	LastModifiedDate time.Time
 }
 
-func (s *SubDirEntry) Fetch() (*Directory, error) {
-	dir, err := s.client.ReadDirFromUrl(s.URL)
+func (s *SubDirEntry) Fetch(ctx context.Context) (*Directory, error) {
+	dir, err := s.client.ReadDirFromUrl(ctx, s.URL)
 	if err != nil {
 		return nil, err
 	}
@@ -158,11 +159,26 @@ type File struct {
 	LastModifiedDate time.Time
 	// The size of the file, as reported by the mirror
 	FileSize int64
+	// Alternative locations of the same file, for mirrors which offer them
+	Mirrors []*url.URL
+	// Hashes of the file published by the mirror, empty when unknown
+	MD5  string
+	SHA1 string
+	// The entity tag reported by the mirror, which changes whenever the file does. Often, but not always, an MD5 hash
+	ETag string
+}
+
+// Returns the strongest hash published by the mirror, or an empty string if there is none
+func (f File) Checksum() string {
+	if f.SHA1 != "" {
+		return f.SHA1
+	}
+	return f.MD5
 }
 
 type Client interface {
 	// This method should be implemented to parse the url string and then call the ReadDirFromUrl method on self
-	ReadDir(urlStr string) (*Directory, error)
+	ReadDir(ctx context.Context, urlStr string) (*Directory, error)
 	// Read a directory given a URL. Returns an error if the client fails to make an HTTP request or parse the resulting data
-	ReadDirFromUrl(u *url.URL) (*Directory, error)
+	ReadDirFromUrl(ctx context.Context, u *url.URL) (*Directory, error)
 }
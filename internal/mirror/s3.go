@@ -0,0 +1,96 @@
+package mirror
+
+import (
+	"context"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/quickemu-project/quickget_configs/internal/data"
+	"github.com/quickemu-project/quickget_configs/internal/web"
+)
+
+// Reads directories from S3-compatible buckets with the ListObjectsV2 API, treating "/" as the directory separator.
+// Directory URLs are the bucket URL followed by the key prefix, e.g. https://bucket.s3.amazonaws.com/some/prefix/
+type S3Client struct {
+	// The path of the bucket on its endpoint, for path-style URLs such as https://s3.example.com/bucket/.
+	// Empty for virtual-hosted buckets, where the bucket is part of the hostname
+	BucketPath string
+}
+
+func (c S3Client) ReadDir(ctx context.Context, urlStr string) (*Directory, error) {
+	u, err := url.Parse(urlStr)
+	if err != nil {
+		return nil, err
+	}
+	return c.ReadDirFromUrl(ctx, u)
+}
+
+func (c S3Client) ReadDirFromUrl(ctx context.Context, u *url.URL) (*Directory, error) {
+	bucketPath := "/" + strings.Trim(c.BucketPath, "/")
+	prefix := strings.TrimPrefix(strings.TrimPrefix(u.Path, bucketPath), "/")
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	bucket := &url.URL{Scheme: u.Scheme, Host: u.Host, Path: strings.TrimSuffix(bucketPath, "/") + "/"}
+
+	files := make(map[string]File)
+	subdirs := make(map[string]SubDirEntry)
+	var token string
+	for {
+		query := url.Values{
+			"list-type": {"2"},
+			"delimiter": {"/"},
+			"prefix":    {prefix},
+		}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		listUrl := *bucket
+		listUrl.RawQuery = query.Encode()
+
+		var result data.S3ListBucketResult
+		if err := web.CapturePageToXml(ctx, &listUrl, &result); err != nil {
+			return nil, err
+		}
+
+		for _, object := range result.Contents {
+			name := strings.TrimPrefix(object.Key, prefix)
+			// Some tools create empty objects to represent directories
+			if name == "" {
+				continue
+			}
+			files[name] = File{
+				Name:             name,
+				URL:              bucket.JoinPath(object.Key),
+				LastModifiedDate: object.LastModified,
+				FileSize:         object.Size,
+				ETag:             strings.Trim(object.ETag, `"`),
+			}
+		}
+		for _, commonPrefix := range result.CommonPrefixes {
+			name := strings.TrimSuffix(strings.TrimPrefix(commonPrefix.Prefix, prefix), "/")
+			if name == "" {
+				continue
+			}
+			// S3 doesn't record modification times for prefixes
+			subdirs[name] = SubDirEntry{
+				client: c,
+				Name:   name,
+				URL:    bucket.JoinPath(commonPrefix.Prefix),
+			}
+		}
+
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			break
+		}
+		token = result.NextContinuationToken
+	}
+
+	return &Directory{
+		Name:    path.Base("/" + prefix),
+		URL:     u,
+		Files:   files,
+		SubDirs: subdirs,
+	}, nil
+}
@@ -1,6 +1,8 @@
 package mirror
 
 import (
+	"context"
+	"encoding/json"
 	"net/url"
 	"path"
 	"slices"
@@ -8,67 +10,56 @@ import (
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
-	"github.com/quickemu-project/quickget_configs/internal/web"
 )
 
-type SourceForgeClient struct{}
+type SourceForgeClient struct {
+	// The SourceForge mirror (e.g. "netix") which files link to.
+	// When empty, files link to downloads.sourceforge.net, which redirects to a mirror near the user
+	Mirror string
+	// Further mirrors to list as alternative locations of each file
+	AlternativeMirrors []string
+}
 
-var (
-	// There's HTML with all of the data we currently use, so there's no need at this time to parse this script
-	//
-	// sourceforgeTopLevelRe     = regexp.MustCompile(`net\.sf\.files\s+=\s+{(.*?)};`)
-	// sourceforgeElementReParts = []string{
-	// 	`"([^"]+)":{`,
-	// 	`"name":"([^"]+)",`,
-	// 	`"path":"([^"]*)",`,
-	// 	`"download_url":"([^"]*)",`,
-	// 	`"url":"([^"]*)",`,
-	// 	`"full_path":"([^"]*)",`,
-	// 	`"type":"(d|f)",`,
-	// 	`"link":"([^"]*)",`,
-	// 	`"downloads":(\d+),`,
-	// 	`"sha1":"([^"]*)",`,
-	// 	`"md5":"([^"]*)",`,
-	// 	`"default":"([^"]*)",`,
-	// 	`"download_label":"([^"]*)",`,
-	// 	`"exclude_reports":(true|false),`,
-	// 	`"downloadable":(true|false),`,
-	// 	`"legacy_release_notes":(null|"[^"]*"),`,
-	// 	`"staged":(true|false),`,
-	// 	`"stage":(\d+),`,
-	// 	`"staging_days":(\d+),`,
-	// 	`"files_url":"([^"]+)",`,
-	// 	`"explicitly_staged":(true|false),`,
-	// 	`"authorized":(null|"[^"]*")`,
-	// 	`}`,
-	// }
-	//sourceforgeElementRe = regexp.MustCompile(strings.Join(sourceforgeElementReParts, ""))
+// Long-standing SourceForge mirrors in Europe, North America and Asia.
+// Kept short, since every mirror of every file is checked during validation
+var SourceForgeMirrors = []string{"netix", "phoenixnap", "jaist"}
+
+// An entry of the net.sf.files object embedded in SourceForge's file listings
+type sourceforgeFile struct {
+	DownloadURL string `json:"download_url"`
+	URL         string `json:"url"`
+	// "f" for files and "d" for directories
+	Type string `json:"type"`
+	SHA1 string `json:"sha1"`
+	MD5  string `json:"md5"`
+}
 
+const sourceforgeFilesVar = "net.sf.files"
+
+var (
 	sourceforgeTopLevelUrl, _ = url.Parse("https://sourceforge.net/")
 	sourceforgeTimeFormat     = time.DateTime + " MST"
 )
 
-func (c SourceForgeClient) ReadDir(urlStr string) (*Directory, error) {
+func (c SourceForgeClient) ReadDir(ctx context.Context, urlStr string) (*Directory, error) {
 	u, err := url.Parse(urlStr)
 	if err != nil {
 		return nil, err
 	}
-	return c.ReadDirFromUrl(u)
+	return c.ReadDirFromUrl(ctx, u)
 }
 
-func (c SourceForgeClient) ReadDirFromUrl(u *url.URL) (*Directory, error) {
-	name := path.Base(u.Path)
-
-	res, err := web.GetResponse(u, nil)
+func (c SourceForgeClient) ReadDirFromUrl(ctx context.Context, u *url.URL) (*Directory, error) {
+	doc, err := getDocument(ctx, u)
 	if err != nil {
 		return nil, err
 	}
-	defer res.Body.Close()
+	return c.parse(u, doc), nil
+}
 
-	doc, err := goquery.NewDocumentFromReader(res.Body)
-	if err != nil {
-		return nil, err
-	}
+func (c SourceForgeClient) parse(u *url.URL, doc *goquery.Document) *Directory {
+	name := path.Base(u.Path)
+	sfFiles := parseSourceforgeFiles(doc)
 
 	files := make(map[string]File)
 	subdirs := make(map[string]SubDirEntry)
@@ -103,20 +94,142 @@ func (c SourceForgeClient) ReadDirFromUrl(u *url.URL) (*Directory, error) {
 			if err != nil {
 				return
 			}
-			files[name] = File{
+			files[name] = c.file(File{
 				Name:             name,
 				URL:              url,
 				LastModifiedDate: dateModified,
 				FileSize:         fileSize,
-			}
+				MD5:              sfFiles[name].MD5,
+				SHA1:             sfFiles[name].SHA1,
+			})
 		}
 
 	})
 
+	// The embedded data may list entries which the table doesn't, but it lacks sizes and dates
+	for name, f := range sfFiles {
+		switch f.Type {
+		case "f":
+			if _, exists := files[name]; exists {
+				continue
+			}
+			url, err := url.Parse(f.DownloadURL)
+			if err != nil || f.DownloadURL == "" {
+				continue
+			}
+			files[name] = c.file(File{
+				Name: name,
+				URL:  url,
+				MD5:  f.MD5,
+				SHA1: f.SHA1,
+			})
+		case "d":
+			if _, exists := subdirs[name]; exists || f.URL == "" {
+				continue
+			}
+			subdirs[name] = SubDirEntry{
+				client: c,
+				Name:   name,
+				URL:    sourceforgeTopLevelUrl.JoinPath(f.URL),
+			}
+		}
+	}
+
 	return &Directory{
 		Name:    name,
 		URL:     u,
 		Files:   files,
 		SubDirs: subdirs,
-	}, nil
+	}
+}
+
+// Extracts the net.sf.files object from the page's scripts, which includes file hashes that the table of files omits
+func parseSourceforgeFiles(doc *goquery.Document) map[string]sourceforgeFile {
+	var sfFiles map[string]sourceforgeFile
+	doc.Find("script").EachWithBreak(func(i int, s *goquery.Selection) bool {
+		script := s.Text()
+		index := strings.Index(script, sourceforgeFilesVar)
+		if index == -1 {
+			return true
+		}
+		script = script[index+len(sourceforgeFilesVar):]
+		start := strings.IndexByte(script, '{')
+		if start == -1 {
+			return true
+		}
+		// The decoder stops at the end of the object, ignoring the rest of the script
+		if err := json.NewDecoder(strings.NewReader(script[start:])).Decode(&sfFiles); err != nil {
+			sfFiles = nil
+			return true
+		}
+		return false
+	})
+	return sfFiles
+}
+
+// Replaces a file's download page URL with direct links to the configured mirrors
+func (c SourceForgeClient) file(f File) File {
+	direct, ok := SourceForgeDirectURL(f.URL, c.Mirror)
+	if !ok {
+		return f
+	}
+	f.URL = direct
+	for _, m := range c.AlternativeMirrors {
+		if m == c.Mirror {
+			continue
+		}
+		if alt, ok := SourceForgeDirectURL(direct, m); ok {
+			f.Mirrors = append(f.Mirrors, alt)
+		}
+	}
+	return f
+}
+
+// Converts a SourceForge file URL (either a project download page or a direct link) to a direct link to a mirror.
+// The mirror picked by downloads.sourceforge.net is used if mirrorName is empty. Returns false if the URL isn't a SourceForge file
+func SourceForgeDirectURL(u *url.URL, mirrorName string) (*url.URL, bool) {
+	var project, filePath string
+	if u.Hostname() == "sourceforge.net" || u.Hostname() == "www.sourceforge.net" {
+		// https://sourceforge.net/projects/<project>/files/<path>/download
+		rest, ok := strings.CutPrefix(u.Path, "/projects/")
+		if !ok {
+			return nil, false
+		}
+		var found bool
+		project, filePath, found = strings.Cut(rest, "/files/")
+		if !found {
+			return nil, false
+		}
+		filePath = strings.TrimSuffix(filePath, "/download")
+		// "latest" is resolved by SourceForge itself, and isn't a path on the mirrors
+		if filePath == "latest" {
+			return nil, false
+		}
+	} else if u.Hostname() == "downloads.sourceforge.net" || strings.HasSuffix(u.Hostname(), ".dl.sourceforge.net") {
+		// https://downloads.sourceforge.net/project/<project>/<path>
+		rest, ok := strings.CutPrefix(u.Path, "/project/")
+		if !ok {
+			return nil, false
+		}
+		var found bool
+		project, filePath, found = strings.Cut(rest, "/")
+		if !found {
+			return nil, false
+		}
+	} else {
+		return nil, false
+	}
+	if project == "" || filePath == "" || strings.HasSuffix(filePath, "/") {
+		return nil, false
+	}
+
+	host := "downloads.sourceforge.net"
+	if mirrorName != "" {
+		host = mirrorName + ".dl.sourceforge.net"
+	}
+	return &url.URL{
+		Scheme: "https",
+		Host:   host,
+		Path:   "/project/" + project + "/" + filePath,
+	}, true
 }
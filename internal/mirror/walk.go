@@ -0,0 +1,164 @@
+package mirror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// The number of subdirectories a single walk fetches at once. Requests are also subject to the limits of each host
+const walkConcurrency = 8
+
+// Called by Walk for each directory reached, one call at a time. path is relative to the directory the walk started from,
+// and depth is the number of directories below it. If fetching a subdirectory failed, dir is nil and err is set.
+// Returns the subdirectories to visit next, which may be none
+type WalkFunc func(path string, depth int, dir *Directory, err error) []SubDirEntry
+
+// Returns every subdirectory of a directory, for a WalkFunc which visits the whole tree
+func AllSubDirs(dir *Directory) []SubDirEntry {
+	return slices.Collect(maps.Values(dir.SubDirs))
+}
+
+// Visits the directory and the subdirectories chosen by fn, fetching several at once. Returns once every visit has completed
+func (d *Directory) Walk(ctx context.Context, fn WalkFunc) {
+	var (
+		wg  sync.WaitGroup
+		mu  sync.Mutex
+		sem = make(chan struct{}, walkConcurrency)
+	)
+	var visit func(p string, depth int, dir *Directory)
+	visit = func(p string, depth int, dir *Directory) {
+		mu.Lock()
+		next := fn(p, depth, dir, nil)
+		mu.Unlock()
+		for _, subdir := range next {
+			subPath := path.Join(p, subdir.Name)
+			wg.Go(func() {
+				sem <- struct{}{}
+				contents, err := subdir.Fetch(ctx)
+				<-sem
+				if err != nil {
+					mu.Lock()
+					fn(subPath, depth+1, nil, err)
+					mu.Unlock()
+					return
+				}
+				visit(subPath, depth+1, contents)
+			})
+		}
+	}
+	visit("", 0, d)
+	wg.Wait()
+}
+
+// A file matched by Glob
+type GlobMatch struct {
+	File File
+	// The path of the file relative to the directory searched, separated by "/"
+	Path string
+	// The text matched by each wildcard or alternative in the pattern, in order
+	Captures []string
+	// The directory containing the file, for finding related files such as checksums
+	Dir *Directory
+}
+
+// Finds files matching a slash-separated pattern relative to the directory, such as "*/releases/*/*.iso".
+// Within each segment, "*" matches any run of characters, "?" a single character, "[...]" a character class,
+// and "{a,b}" any of the alternatives. Only subdirectories which the pattern can match are fetched.
+// Matches are sorted by path. Errors fetching subdirectories are joined and returned alongside the matches found elsewhere
+func (d *Directory) Glob(ctx context.Context, pattern string) ([]GlobMatch, error) {
+	var segments []*regexp.Regexp
+	for _, segment := range strings.Split(strings.Trim(pattern, "/"), "/") {
+		re, err := compileGlobSegment(segment)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid glob pattern %q: %w", pattern, err)
+		}
+		segments = append(segments, re)
+	}
+	last := len(segments) - 1
+
+	var matches []GlobMatch
+	var errs []error
+	// The captures of the directories leading to each path being visited
+	captures := map[string][]string{"": nil}
+	d.Walk(ctx, func(p string, depth int, dir *Directory, err error) []SubDirEntry {
+		if err != nil {
+			errs = append(errs, err)
+			return nil
+		}
+		parent := captures[p]
+		delete(captures, p)
+		if depth == last {
+			for name, f := range dir.Files {
+				if m := segments[depth].FindStringSubmatch(name); m != nil {
+					matches = append(matches, GlobMatch{
+						File:     f,
+						Path:     path.Join(p, name),
+						Captures: append(slices.Clone(parent), m[1:]...),
+						Dir:      dir,
+					})
+				}
+			}
+			return nil
+		}
+		var next []SubDirEntry
+		for name, subdir := range dir.SubDirs {
+			if m := segments[depth].FindStringSubmatch(name); m != nil {
+				captures[path.Join(p, name)] = append(slices.Clone(parent), m[1:]...)
+				next = append(next, subdir)
+			}
+		}
+		return next
+	})
+
+	slices.SortFunc(matches, func(a, b GlobMatch) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+	return matches, errors.Join(errs...)
+}
+
+// Converts one segment of a glob pattern to an anchored regular expression, with a group for each wildcard
+func compileGlobSegment(segment string) (*regexp.Regexp, error) {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for i := 0; i < len(segment); i++ {
+		switch c := segment[i]; c {
+		case '*':
+			sb.WriteString("(.*)")
+		case '?':
+			sb.WriteString("(.)")
+		case '[':
+			end := strings.IndexByte(segment[i+1:], ']')
+			if end == -1 {
+				return nil, errors.New("unterminated character class")
+			}
+			class := segment[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			sb.WriteString("([" + class + "])")
+			i += end + 1
+		case '{':
+			end := strings.IndexByte(segment[i+1:], '}')
+			if end == -1 {
+				return nil, errors.New("unterminated alternatives")
+			}
+			alternatives := strings.Split(segment[i+1:i+1+end], ",")
+			for j, alt := range alternatives {
+				alternatives[j] = regexp.QuoteMeta(alt)
+			}
+			sb.WriteString("(" + strings.Join(alternatives, "|") + ")")
+			i += end + 1
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	sb.WriteString("$")
+	return regexp.Compile(sb.String())
+}
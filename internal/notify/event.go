@@ -0,0 +1,144 @@
+package notify
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/quickemu-project/quickget_configs/internal/data"
+	"github.com/quickemu-project/quickget_configs/internal/status"
+	qgdata "github.com/quickemu-project/quickget_configs/pkg/quickgetdata"
+)
+
+// What happened to an OS since the previous run
+type EventKind string
+
+const (
+	// The OS has a release which wasn't in the previous run's data
+	NewRelease EventKind = "new_release"
+	// The OS was in the previous run's data, but produced no configs in this run
+	OSFailed EventKind = "os_failed"
+	// The OS produced configs after failing in its previous run. Needs the run history
+	OSRecovered EventKind = "os_recovered"
+	// The OS produced configs for the first time. Needs the run history
+	NewOS EventKind = "new_os"
+	// A published checksum didn't match the one recorded for a source
+	ChecksumMismatch EventKind = "checksum_mismatch"
+)
+
+// Every event kind, in the order they're reported
+var EventKinds = []EventKind{OSFailed, OSRecovered, NewOS, NewRelease, ChecksumMismatch}
+
+type Event struct {
+	Kind       EventKind `json:"kind"`
+	OS         string    `json:"os"`
+	PrettyName string    `json:"pretty_name"`
+	// The config involved, if the event concerns one
+	Release string      `json:"release,omitempty"`
+	Edition string      `json:"edition,omitempty"`
+	Arch    qgdata.Arch `json:"arch,omitempty"`
+	// A sentence describing the event, for chat messages
+	Message string `json:"message"`
+}
+
+// Returns the events of the run, sorted by kind then OS. Without previous data, only checksum mismatches can be found
+func Detect(previous, current []qgdata.OSData, report status.Report) []Event {
+	previousByName := make(map[string]qgdata.OSData, len(previous))
+	for _, os := range previous {
+		previousByName[os.Name] = os
+	}
+	currentByName := make(map[string]qgdata.OSData, len(current))
+	for _, os := range current {
+		currentByName[os.Name] = os
+	}
+
+	var events []Event
+	for _, os := range report.OS {
+		before, wasPresent := previousByName[os.Name]
+		switch {
+		case len(previous) == 0:
+		case wasPresent && !os.OK:
+			reason := "no configs were produced"
+			if os.Error != nil {
+				reason = os.Error.Message
+			}
+			events = append(events, Event{
+				Kind:       OSFailed,
+				OS:         os.Name,
+				PrettyName: os.PrettyName,
+				Message:    fmt.Sprintf("%s is failing: %s", os.PrettyName, reason),
+			})
+		// Only the history can tell an OS which failed last time from one which was just added
+		case !wasPresent && os.OK && os.WasFailing:
+			events = append(events, Event{
+				Kind:       OSRecovered,
+				OS:         os.Name,
+				PrettyName: os.PrettyName,
+				Message:    fmt.Sprintf("%s is producing configs again", os.PrettyName),
+			})
+		case !wasPresent && os.OK && os.New:
+			events = append(events, Event{
+				Kind:       NewOS,
+				OS:         os.Name,
+				PrettyName: os.PrettyName,
+				Message:    fmt.Sprintf("New OS: %s", os.PrettyName),
+			})
+		}
+
+		if after, isPresent := currentByName[os.Name]; wasPresent && isPresent {
+			for _, release := range newReleases(before, after) {
+				events = append(events, Event{
+					Kind:       NewRelease,
+					OS:         os.Name,
+					PrettyName: os.PrettyName,
+					Release:    release,
+					Message:    fmt.Sprintf("New release: %s %s", os.PrettyName, release),
+				})
+			}
+		}
+
+		for _, failure := range os.ChecksumFailures {
+			if failure.Error.Kind != data.ChecksumMismatch {
+				continue
+			}
+			events = append(events, Event{
+				Kind:       ChecksumMismatch,
+				OS:         os.Name,
+				PrettyName: os.PrettyName,
+				Release:    failure.Release,
+				Edition:    failure.Edition,
+				Arch:       failure.Arch,
+				Message:    fmt.Sprintf("Checksum mismatch in %s %s: %s", os.PrettyName, configName(failure.Release, failure.Edition, failure.Arch), failure.Error.Message),
+			})
+		}
+	}
+	slices.SortStableFunc(events, func(a, b Event) int {
+		return slices.Index(EventKinds, a.Kind) - slices.Index(EventKinds, b.Kind)
+	})
+	return events
+}
+
+// Returns the releases of after which weren't in before, in the order they appear
+func newReleases(before, after qgdata.OSData) []string {
+	known := make(map[string]bool, len(before.Releases))
+	for _, config := range before.Releases {
+		known[config.Release] = true
+	}
+	var releases []string
+	for _, config := range after.Releases {
+		if !known[config.Release] && !slices.Contains(releases, config.Release) {
+			releases = append(releases, config.Release)
+		}
+	}
+	return releases
+}
+
+func configName(release, edition string, arch qgdata.Arch) string {
+	name := release
+	if edition != "" {
+		name += " (" + edition + ")"
+	}
+	if arch != "" {
+		name += " - " + string(arch)
+	}
+	return name
+}
@@ -0,0 +1,233 @@
+package notify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/quickemu-project/quickget_configs/internal/web"
+)
+
+// The payload sent to a webhook
+type Format string
+
+const (
+	// The events as structured JSON
+	JSON Format = "json"
+	// An m.room.message event, sent to a room's send endpoint
+	Matrix Format = "matrix"
+	// A Discord webhook message
+	Discord Format = "discord"
+	// A Slack incoming webhook message
+	Slack Format = "slack"
+)
+
+// Discord rejects messages with longer content
+const discordMaxLength = 2000
+
+// Slack treats these characters as markup in message text
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// The notification settings, read from a JSON file
+type Config struct {
+	// The status page, linked from chat messages if set
+	StatusPage string    `json:"status_page"`
+	Webhooks   []Webhook `json:"webhooks"`
+}
+
+type Webhook struct {
+	// The endpoint events are sent to. For Matrix, this is a room's send endpoint ending in "/send/m.room.message"
+	URL    string `json:"url"`
+	Format Format `json:"format"`
+	// The kinds of events sent to the webhook. Every kind is sent if empty
+	Events []EventKind `json:"events"`
+	// Extra request headers, such as Authorization for Matrix
+	Headers map[string]string `json:"headers"`
+}
+
+// Reads the notification settings. URLs and headers may refer to environment variables as $VAR or ${VAR}, so secrets can be kept out of the file
+func Load(path string) (*Config, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config Config
+	if err := json.Unmarshal(contents, &config); err != nil {
+		return nil, fmt.Errorf("Invalid notification config %s: %w", path, err)
+	}
+	for i := range config.Webhooks {
+		webhook := &config.Webhooks[i]
+		webhook.URL = os.ExpandEnv(webhook.URL)
+		for name, value := range webhook.Headers {
+			webhook.Headers[name] = os.ExpandEnv(value)
+		}
+		if webhook.Format == "" {
+			webhook.Format = JSON
+		}
+		if !slices.Contains([]Format{JSON, Matrix, Discord, Slack}, webhook.Format) {
+			return nil, fmt.Errorf("Webhook %d has unknown format %q", i+1, webhook.Format)
+		}
+		for _, kind := range webhook.Events {
+			if !slices.Contains(EventKinds, kind) {
+				return nil, fmt.Errorf("Webhook %d has unknown event kind %q", i+1, kind)
+			}
+		}
+	}
+	return &config, nil
+}
+
+// Sends the events to every webhook which accepts any of them. Errors don't include webhook URLs, since they often contain tokens
+func (c *Config) Send(start time.Time, events []Event) error {
+	var errs []error
+	for i, webhook := range c.Webhooks {
+		selected := events
+		if len(webhook.Events) > 0 {
+			selected = slices.DeleteFunc(slices.Clone(events), func(e Event) bool {
+				return !slices.Contains(webhook.Events, e.Kind)
+			})
+		}
+		if len(selected) == 0 {
+			continue
+		}
+		if err := c.send(webhook, start, selected); err != nil {
+			errs = append(errs, fmt.Errorf("Failed to notify webhook %d (%s): %w", i+1, webhook.Format, redact(err)))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (c *Config) send(webhook Webhook, start time.Time, events []Event) error {
+	headers := make(http.Header)
+	for name, value := range webhook.Headers {
+		headers.Set(name, value)
+	}
+	method, endpoint := http.MethodPost, webhook.URL
+	var payload any
+	switch webhook.Format {
+	case JSON:
+		payload = jsonPayload{Start: start, StatusPage: c.StatusPage, Events: events}
+	case Matrix:
+		payload = matrixPayload{
+			MsgType:       "m.notice",
+			Body:          c.text(events, 0),
+			Format:        "org.matrix.custom.html",
+			FormattedBody: c.html(events),
+		}
+		// Sending a room event requires a unique transaction ID, which lets the server discard retried requests
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return err
+		}
+		u = u.JoinPath(strconv.FormatInt(time.Now().UnixNano(), 10))
+		method, endpoint = http.MethodPut, u.String()
+	case Discord:
+		discord := discordPayload{Username: "quickget configs", Content: c.text(events, discordMaxLength)}
+		discord.AllowedMentions.Parse = []string{}
+		payload = discord
+	case Slack:
+		payload = slackPayload{Text: slackEscaper.Replace(c.text(events, 0))}
+	}
+	return web.SendJSON(method, endpoint, headers, payload)
+}
+
+type jsonPayload struct {
+	// The start of the run which found the events
+	Start      time.Time `json:"start"`
+	StatusPage string    `json:"status_page,omitempty"`
+	Events     []Event   `json:"events"`
+}
+
+type matrixPayload struct {
+	MsgType       string `json:"msgtype"`
+	Body          string `json:"body"`
+	Format        string `json:"format"`
+	FormattedBody string `json:"formatted_body"`
+}
+
+type discordPayload struct {
+	Username string `json:"username"`
+	Content  string `json:"content"`
+	// Left empty so text in events can't ping anyone
+	AllowedMentions struct {
+		Parse []string `json:"parse"`
+	} `json:"allowed_mentions"`
+}
+
+type slackPayload struct {
+	Text string `json:"text"`
+}
+
+func (c *Config) heading(events []Event) string {
+	if len(events) == 1 {
+		return "quickget configs: 1 event"
+	}
+	return fmt.Sprintf("quickget configs: %d events", len(events))
+}
+
+// Lists the events as plain text, leaving out those which would make it longer than maxLength if it's positive
+func (c *Config) text(events []Event, maxLength int) string {
+	lines := []string{c.heading(events)}
+	length := len(lines[0]) + len(c.StatusPage) + 1
+	for i, event := range events {
+		line := "- " + event.Message
+		if maxLength > 0 {
+			more := fmt.Sprintf("... and %d more", len(events)-i)
+			if length+len(line)+1+len(more)+1 > maxLength {
+				lines = append(lines, more)
+				break
+			}
+		}
+		lines = append(lines, line)
+		length += len(line) + 1
+	}
+	if c.StatusPage != "" {
+		lines = append(lines, c.StatusPage)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (c *Config) html(events []Event) string {
+	var b strings.Builder
+	b.WriteString("<p><strong>" + html.EscapeString(c.heading(events)) + "</strong></p><ul>")
+	for _, event := range events {
+		b.WriteString("<li>" + html.EscapeString(event.Message) + "</li>")
+	}
+	b.WriteString("</ul>")
+	if c.StatusPage != "" {
+		b.WriteString(`<p><a href="` + html.EscapeString(c.StatusPage) + `">Status page</a></p>`)
+	}
+	return b.String()
+}
+
+// Removes URLs from an error, keeping the status or underlying network error
+func redact(err error) error {
+	var statusErr *web.StatusError
+	var urlErr *url.Error
+	switch {
+	case errors.As(err, &statusErr):
+		return errors.New(statusErr.Status)
+	case errors.As(err, &urlErr):
+		return urlErr.Err
+	default:
+		return err
+	}
+}
+
+// Returns an event of every kind for an example OS, for checking that webhooks are set up correctly
+func SampleEvents() []Event {
+	return []Event{
+		{Kind: OSFailed, OS: "example", PrettyName: "Example OS", Message: "Example OS is failing: this is a test notification"},
+		{Kind: OSRecovered, OS: "example", PrettyName: "Example OS", Message: "Example OS is producing configs again"},
+		{Kind: NewOS, OS: "example", PrettyName: "Example OS", Message: "New OS: Example OS"},
+		{Kind: NewRelease, OS: "example", PrettyName: "Example OS", Release: "1.0", Message: "New release: Example OS 1.0"},
+		{Kind: ChecksumMismatch, OS: "example", PrettyName: "Example OS", Release: "1.0", Arch: "x86_64", Message: "Checksum mismatch in Example OS 1.0 - x86_64: this is a test notification"},
+	}
+}
@@ -0,0 +1,102 @@
+package notify
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/quickemu-project/quickget_configs/internal/status"
+	qgdata "github.com/quickemu-project/quickget_configs/pkg/quickgetdata"
+)
+
+func TestSendJSON(t *testing.T) {
+	previous := []qgdata.OSData{
+		{Name: "failing", PrettyName: "Failing OS", Releases: []qgdata.Config{{Release: "1"}}},
+	}
+	current := []qgdata.OSData{
+		{Name: "recovered", PrettyName: "Recovered OS", Releases: []qgdata.Config{{Release: "1"}}},
+		{Name: "added", PrettyName: "Added OS", Releases: []qgdata.Config{{Release: "1"}}},
+	}
+	report := status.Report{OS: []status.OSReport{
+		{Name: "added", PrettyName: "Added OS", OK: true, New: true},
+		{Name: "failing", PrettyName: "Failing OS", Error: &status.ErrorReport{Message: "Received status code 404"}},
+		{Name: "recovered", PrettyName: "Recovered OS", OK: true, WasFailing: true},
+	}}
+	events := Detect(previous, current, report)
+
+	var requests atomic.Int32
+	var body map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Method != http.MethodPost {
+			t.Errorf("Method = %s, want POST", r.Method)
+		}
+		if got := r.Header.Get("Content-Type"); got != "application/json" {
+			t.Errorf("Content-Type = %q, want application/json", got)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer token" {
+			t.Errorf("Authorization = %q, want the configured header", got)
+		}
+		contents, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(contents, &body); err != nil {
+			t.Errorf("Invalid payload %s: %v", contents, err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	config := Config{
+		StatusPage: "https://example.org/status",
+		Webhooks:   []Webhook{{URL: server.URL, Format: JSON, Headers: map[string]string{"Authorization": "Bearer token"}}},
+	}
+	if err := config.Send(start, events); err != nil {
+		t.Fatalf("Send() = %v", err)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Fatalf("Made %d requests, want 1", n)
+	}
+
+	want := map[string]any{
+		"start":       "2024-01-01T00:00:00Z",
+		"status_page": "https://example.org/status",
+		"events": []any{
+			map[string]any{"kind": "os_failed", "os": "failing", "pretty_name": "Failing OS", "message": "Failing OS is failing: Received status code 404"},
+			map[string]any{"kind": "os_recovered", "os": "recovered", "pretty_name": "Recovered OS", "message": "Recovered OS is producing configs again"},
+			map[string]any{"kind": "new_os", "os": "added", "pretty_name": "Added OS", "message": "New OS: Added OS"},
+		},
+	}
+	if !reflect.DeepEqual(body, want) {
+		t.Errorf("Payload = %v, want %v", body, want)
+	}
+}
+
+func TestSendErrorStatus(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	config := Config{Webhooks: []Webhook{{URL: server.URL + "/secret-token", Format: JSON}}}
+	err := config.Send(time.Now(), SampleEvents())
+	if err == nil {
+		t.Fatal("Send() succeeded, want an error")
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("Made %d requests, want 1 since webhooks aren't retried", n)
+	}
+	if !strings.Contains(err.Error(), "502") {
+		t.Errorf("Send() = %q, want the status", err)
+	}
+	if strings.Contains(err.Error(), "secret-token") {
+		t.Errorf("Send() = %q, which includes the webhook URL", err)
+	}
+}
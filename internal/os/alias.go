@@ -10,20 +10,30 @@ type (
 	OSData        = utils.OSData
 	OS            = utils.OS
 	Config        = utils.Config
-	GithubAPI     = data.GithubAPI
-	GithubAsset   = data.GithubAsset
 	Arch          = qgdata.Arch
 	ArchiveFormat = qgdata.ArchiveFormat
 	Source        = qgdata.Source
 	Disk          = qgdata.Disk
+	Hardware      = qgdata.Hardware
+	WebSource     = qgdata.WebSource
 	Failure       = data.Failure
 	Validation    = qgdata.Validation
 )
 
 const (
-	x86_64  = qgdata.X86_64
-	aarch64 = qgdata.Aarch64
-	riscv64 = qgdata.Riscv64
+	x86_64      = qgdata.X86_64
+	aarch64     = qgdata.Aarch64
+	riscv64     = qgdata.Riscv64
+	i686        = qgdata.I686
+	armv7       = qgdata.Armv7
+	ppc64le     = qgdata.Ppc64le
+	s390x       = qgdata.S390x
+	loongarch64 = qgdata.Loongarch64
+)
+
+const (
+	mib = qgdata.MiB
+	gib = qgdata.GiB
 )
 
 var (
@@ -31,6 +41,7 @@ var (
 	webSource         = qgdata.NewWebSource
 	urlChecksumSource = qgdata.URLChecksumSource
 	urlSource         = qgdata.URLSource
+	kernelSource      = qgdata.NewKernelSource
 )
 
 var (
@@ -147,3 +158,13 @@ var List = []OS{
 	Xubuntu,
 	Zorin,
 }
+
+// Creates an error for a page or directory which doesn't contain what the provider looks for
+func noMatch(format string, a ...any) error {
+	return data.Errorf(data.Parse, format, a...)
+}
+
+// Creates an error for a checksum which the provider couldn't find
+func missingChecksum(format string, a ...any) error {
+	return data.Errorf(data.ChecksumMissing, format, a...)
+}
@@ -1,11 +1,13 @@
 package os
 
 import (
+	"context"
 	"regexp"
 
 	"github.com/quickemu-project/quickget_configs/internal/cs"
 	"github.com/quickemu-project/quickget_configs/internal/mirror"
 	"github.com/quickemu-project/quickget_configs/internal/utils"
+	"github.com/quickemu-project/quickget_configs/pkg/quickgetdata"
 )
 
 const (
@@ -20,9 +22,9 @@ var Alma = OS{
 	ConfigFunction: createAlmaConfigs,
 }
 
-func createAlmaConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createAlmaConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	c := mirror.LegacyHttpClient{}
-	head, err := c.ReadDir(almaMirror)
+	head, err := c.ReadDir(ctx, almaMirror)
 	if err != nil {
 		return nil, err
 	}
@@ -35,13 +37,18 @@ func createAlmaConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 	for _, d := range releases {
 		release := d.Name
 		wg.Go(func() {
-			architectures, err := d.Fetch()
+			architectures, err := d.Fetch(ctx)
 			if err != nil {
 				errs <- Failure{Release: release, Error: err}
 				return
 			}
+			if cd, ok := architectures.SubDirs["cloud"]; ok {
+				wg.Go(func() {
+					addAlmaCloudConfigs(ctx, release, cd, ch, errs, csErrs)
+				})
+			}
 			if id, ok := architectures.SubDirs["isos"]; ok {
-				architectures, err = id.Fetch()
+				architectures, err = id.Fetch(ctx)
 				if err != nil {
 					errs <- Failure{Release: release, Error: err}
 					return
@@ -49,7 +56,7 @@ func createAlmaConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 			}
 			for _, arch := range three_architectures {
 				if d, ok := architectures.SubDirs[string(arch)]; ok {
-					contents, err := d.Fetch()
+					contents, err := d.Fetch(ctx)
 					if err != nil {
 						errs <- Failure{Release: release, Arch: arch, Error: err}
 						return
@@ -57,7 +64,7 @@ func createAlmaConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 
 					checksums := make(map[string]string)
 					if f, ok := contents.Files["CHECKSUM"]; ok {
-						checksums, err = cs.Build(cs.Sha256Regex, f.URL)
+						checksums, err = cs.Build(ctx, cs.Sha256Regex, f.URL)
 						if err != nil {
 							csErrs <- Failure{Release: release, Arch: arch, Error: err}
 							return
@@ -82,3 +89,53 @@ func createAlmaConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 
 	return waitForConfigs(ch, wg), nil
 }
+
+func addAlmaCloudConfigs(ctx context.Context, release string, d mirror.SubDirEntry, ch chan<- Config, errs, csErrs chan<- Failure) {
+	architectures, err := d.Fetch(ctx)
+	if err != nil {
+		errs <- Failure{Release: release, Edition: "cloud", Error: err}
+		return
+	}
+	imageRe := regexp.MustCompile(`^AlmaLinux-\d+-GenericCloud-latest\.[^.]+\.qcow2$`)
+	for _, arch := range three_architectures {
+		ad, ok := architectures.SubDirs[string(arch)]
+		if !ok {
+			continue
+		}
+		contents, err := ad.Fetch(ctx)
+		if err != nil {
+			errs <- Failure{Release: release, Edition: "cloud", Arch: arch, Error: err}
+			continue
+		}
+		if id, ok := contents.SubDirs["images"]; ok {
+			contents, err = id.Fetch(ctx)
+			if err != nil {
+				errs <- Failure{Release: release, Edition: "cloud", Arch: arch, Error: err}
+				continue
+			}
+		}
+
+		checksums := make(map[string]string)
+		if f, ok := contents.Files["CHECKSUM"]; ok {
+			checksums, err = cs.Build(ctx, cs.Sha256Regex, f.URL)
+			if err != nil {
+				csErrs <- Failure{Release: release, Edition: "cloud", Arch: arch, Error: err}
+			}
+		}
+
+		for f := range contents.MatchingFiles(imageRe) {
+			ch <- Config{
+				Release: release,
+				Edition: "cloud",
+				Arch:    arch,
+				DiskImages: []Disk{
+					{
+						Source: webSource(f.URL.String(), checksums[f.Name], "", f.Name),
+						Format: quickgetdata.Qcow2,
+					},
+				},
+				CloudInit: true,
+			}
+		}
+	}
+}
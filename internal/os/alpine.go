@@ -1,18 +1,34 @@
 package os
 
 import (
+	"context"
 	"fmt"
 	"regexp"
+	"strings"
 
+	"github.com/quickemu-project/quickget_configs/internal/mirror"
 	"github.com/quickemu-project/quickget_configs/internal/web"
 )
 
 const (
-	alpineMirror    = "https://dl-cdn.alpinelinux.org/alpine/"
-	alpineReleaseRe = `<a href="(v[0-9]+\.[0-9]+)/"`
-	alpineIsoRe     = `(?s)iso: (alpine-virt-[0-9]+\.[0-9]+.*?.iso).*? sha256: ([0-9a-f]+)`
+	alpineMirror        = "https://dl-cdn.alpinelinux.org/alpine/"
+	alpineReleaseRe     = `<a href="(v[0-9]+\.[0-9]+)/"`
+	alpineVirtIsoRe     = `(?s)iso: (alpine-virt-[0-9]+\.[0-9]+.*?.iso).*? sha256: ([0-9a-f]+)`
+	alpineStandardIsoRe = `(?s)iso: (alpine-standard-[0-9]+\.[0-9]+.*?.iso).*? sha256: ([0-9a-f]+)`
 )
 
+// Alpine's directory names for each architecture it publishes ISOs for
+var alpineArchitectures = map[string]Arch{
+	"x86_64":      x86_64,
+	"aarch64":     aarch64,
+	"x86":         i686,
+	"armv7":       armv7,
+	"ppc64le":     ppc64le,
+	"s390x":       s390x,
+	"riscv64":     riscv64,
+	"loongarch64": loongarch64,
+}
+
 var Alpine = OS{
 	Name:           "alpine",
 	PrettyName:     "Alpine Linux",
@@ -21,38 +37,74 @@ var Alpine = OS{
 	ConfigFunction: createAlpineConfigs,
 }
 
-func createAlpineConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
-	releases, _, err := getBasicReleases(alpineMirror, alpineReleaseRe, -1)
+func createAlpineConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+	releases, _, err := getBasicReleases(ctx, alpineMirror, alpineReleaseRe, -1)
 	if err != nil {
 		return nil, err
 	}
 	ch, wg := getChannels()
-	isoRe := regexp.MustCompile(alpineIsoRe)
+	virtRe := regexp.MustCompile(alpineVirtIsoRe)
+	standardRe := regexp.MustCompile(alpineStandardIsoRe)
+	c := mirror.LegacyHttpClient{}
 
 	for release := range releases {
-		for _, arch := range x86_64_aarch64 {
-			mirror := fmt.Sprintf("%s%s/releases/%s/", alpineMirror, release, arch)
-			releaseUrl := mirror + "latest-releases.yaml"
-			wg.Go(func() {
-				page, err := web.CapturePage(releaseUrl)
-				if err != nil {
-					errs <- Failure{Release: release, Arch: arch, Error: err}
-					return
+		wg.Go(func() {
+			// Architectures were added over time, so only request those present for this release
+			head, err := c.ReadDir(ctx, fmt.Sprintf("%s%s/releases/", alpineMirror, release))
+			if err != nil {
+				errs <- Failure{Release: release, Error: err}
+				return
+			}
+			for name := range head.SubDirs {
+				arch, ok := alpineArchitectures[name]
+				if !ok {
+					continue
 				}
+				archMirror := fmt.Sprintf("%s%s/releases/%s/", alpineMirror, release, name)
+				releaseUrl := archMirror + "latest-releases.yaml"
+				wg.Go(func() {
+					page, err := web.CapturePage(ctx, releaseUrl)
+					if err != nil {
+						errs <- Failure{Release: release, Arch: arch, Error: err}
+						return
+					}
 
-				if slice := isoRe.FindStringSubmatch(page); len(slice) > 0 {
-					iso, checksum := slice[1], slice[2]
-					url := mirror + iso
-					ch <- Config{
-						Release: release,
-						Arch:    arch,
-						ISO: []Source{
-							urlChecksumSource(url, checksum),
-						},
+					// The virt flavour is preferred, but isn't built for every architecture
+					slice := virtRe.FindStringSubmatch(page)
+					if len(slice) == 0 {
+						slice = standardRe.FindStringSubmatch(page)
 					}
-				}
-			})
-		}
+					if len(slice) > 0 {
+						iso, checksum := slice[1], slice[2]
+						url := archMirror + iso
+						ch <- Config{
+							Release: release,
+							Arch:    arch,
+							ISO: []Source{
+								urlChecksumSource(url, checksum),
+							},
+						}
+					}
+
+					if strings.Contains(page, "flavor: alpine-netboot") {
+						netbootMirror := archMirror + "netboot/"
+						cmdline := fmt.Sprintf("ip=dhcp alpine_repo=%s%s/main modloop=%smodloop-lts", alpineMirror, release, netbootMirror)
+						ch <- Config{
+							Release: release,
+							Edition: "netboot",
+							Arch:    arch,
+							KernelBoot: []Source{
+								kernelSource(
+									WebSource{URL: netbootMirror + "vmlinuz-lts"},
+									[]WebSource{{URL: netbootMirror + "initramfs-lts"}},
+									cmdline,
+								),
+							},
+						}
+					}
+				})
+			}
+		})
 	}
 
 	return waitForConfigs(ch, wg), nil
@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"regexp"
 	"strings"
 
@@ -21,9 +22,9 @@ var AntiX = OS{
 	ConfigFunction: createAntiXConfigs,
 }
 
-func createAntiXConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
-	c := mirror.SourceForgeClient{}
-	head, err := c.ReadDir(antiXMirror)
+func createAntiXConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+	c := sourceforgeClient
+	head, err := c.ReadDir(ctx, antiXMirror)
 	if err != nil {
 		return nil, err
 	}
@@ -37,7 +38,7 @@ func createAntiXConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 	var addConfigs func(release string, d mirror.SubDirEntry, edition string)
 	addConfigs = func(release string, d mirror.SubDirEntry, edition string) {
 		wg.Go(func() {
-			contents, err := d.Fetch()
+			contents, err := d.Fetch(ctx)
 			if err != nil {
 				errs <- Failure{Release: release, Edition: edition, Error: err}
 				return
@@ -51,11 +52,12 @@ func createAntiXConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 			}
 
 			for f, match := range contents.FileMatches(isoRe) {
-				var checksum string
+				checksum := f.Checksum()
 				if cf, ok := contents.Files[f.Name+".sha256"]; ok {
-					checksum, err = cs.SingleWhitespace(cf)
-					if err != nil {
+					if sum, err := cs.SingleWhitespace(ctx, cf); err != nil {
 						csErrs <- Failure{Release: release, Edition: edition, Error: err}
+					} else {
+						checksum = sum
 					}
 				}
 				edition := match[1] + "-" + edition
@@ -63,7 +65,7 @@ func createAntiXConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 					Release: release,
 					Edition: edition,
 					ISO: []Source{
-						webSource(f.URL.String(), checksum, "", f.Name),
+						mirrorFileSource(f, checksum),
 					},
 				}
 			}
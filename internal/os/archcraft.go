@@ -1,7 +1,7 @@
 package os
 
 import (
-	"errors"
+	"context"
 	"strings"
 
 	"github.com/quickemu-project/quickget_configs/internal/cs"
@@ -21,9 +21,9 @@ var Archcraft = OS{
 	ConfigFunction: createArchcraftConfigs,
 }
 
-func createArchcraftConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
-	c := mirror.SourceForgeClient{}
-	head, err := c.ReadDir(archcraftMirror)
+func createArchcraftConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+	c := sourceforgeClient
+	head, err := c.ReadDir(ctx, archcraftMirror)
 	if err != nil {
 		return nil, err
 	}
@@ -35,7 +35,7 @@ func createArchcraftConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 	for _, d := range releases {
 		release := d.Name
 		wg.Go(func() {
-			contents, err := d.Fetch()
+			contents, err := d.Fetch(ctx)
 			if err != nil {
 				errs <- Failure{Release: release, Error: err}
 				return
@@ -44,25 +44,26 @@ func createArchcraftConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 				return strings.HasSuffix(f.Name, ".iso")
 			})
 			if !ok {
-				errs <- Failure{Release: release, Error: errors.New("could not find ISO in directory")}
+				errs <- Failure{Release: release, Error: noMatch("could not find ISO in directory")}
 				return
 			}
 
-			var checksum string
+			checksum := f.Checksum()
 			cf, ok := contents.FindFile(func(f2 mirror.File) bool {
 				return strings.HasPrefix(f2.Name, f.Name) && strings.HasSuffix(f2.Name, "sum")
 			})
 			if ok {
-				checksum, err = cs.SingleWhitespace(cf)
-				if err != nil {
+				if sum, err := cs.SingleWhitespace(ctx, cf); err != nil {
 					csErrs <- Failure{Release: release, Error: err}
+				} else {
+					checksum = sum
 				}
 			}
 
 			ch <- Config{
 				Release: release,
 				ISO: []Source{
-					webSource(f.URL.String(), checksum, "", f.Name),
+					mirrorFileSource(f, checksum),
 				},
 			}
 		})
@@ -1,6 +1,11 @@
 package os
 
-import "github.com/quickemu-project/quickget_configs/internal/web"
+import (
+	"context"
+	"path"
+
+	"github.com/quickemu-project/quickget_configs/internal/web"
+)
 
 const (
 	archLinuxAPI    = "https://archlinux.org/releng/releases/json/"
@@ -15,14 +20,14 @@ var ArchLinux = OS{
 	ConfigFunction: createArchLinuxConfigs,
 }
 
-func createArchLinuxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createArchLinuxConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	var apiData archAPI
-	if err := web.CapturePageToJson(archLinuxAPI, &apiData); err != nil {
+	if err := web.CapturePageToJson(ctx, archLinuxAPI, &apiData); err != nil {
 		return nil, err
 	}
 
 	numConfigs := min(3, len(apiData.Releases))
-	configs := make([]Config, numConfigs)
+	configs := make([]Config, 0, numConfigs*2)
 	for i := 0; i < numConfigs; i++ {
 		data := apiData.Releases[i]
 		release := data.Version
@@ -30,12 +35,27 @@ func createArchLinuxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 			release = "latest"
 		}
 		url := archLinuxMirror + data.IsoURL
-		configs[i] = Config{
-			Release: release,
-			ISO: []Source{
-				urlChecksumSource(url, data.Sha256Sum),
+		releaseDir := archLinuxMirror + path.Dir(data.IsoURL) + "/"
+		configs = append(configs,
+			Config{
+				Release: release,
+				ISO: []Source{
+					urlChecksumSource(url, data.Sha256Sum),
+				},
 			},
-		}
+			// archiso can fetch its root filesystem over HTTP, so the kernel in the release directory can be booted directly
+			Config{
+				Release: release,
+				Edition: "netboot",
+				KernelBoot: []Source{
+					kernelSource(
+						WebSource{URL: releaseDir + "arch/boot/x86_64/vmlinuz-linux"},
+						[]WebSource{{URL: releaseDir + "arch/boot/x86_64/initramfs-linux.img"}},
+						"archisobasedir=arch archiso_http_srv="+releaseDir+" ip=dhcp",
+					),
+				},
+			},
+		)
 	}
 
 	return configs, nil
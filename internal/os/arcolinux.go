@@ -1,10 +1,10 @@
 package os
 
 import (
+	"context"
 	"regexp"
 
 	"github.com/quickemu-project/quickget_configs/internal/cs"
-	"github.com/quickemu-project/quickget_configs/internal/mirror"
 )
 
 const (
@@ -20,9 +20,9 @@ var ArcoLinux = OS{
 	ConfigFunction: createArcoLinuxConfigs,
 }
 
-func createArcoLinuxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
-	c := mirror.SourceForgeClient{}
-	head, err := c.ReadDir(arcoLinuxMirror)
+func createArcoLinuxConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+	c := sourceforgeClient
+	head, err := c.ReadDir(ctx, arcoLinuxMirror)
 	if err != nil {
 		return nil, err
 	}
@@ -32,7 +32,7 @@ func createArcoLinuxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 
 	for edition, d := range head.SubDirs {
 		wg.Go(func() {
-			contents, err := d.Fetch()
+			contents, err := d.Fetch(ctx)
 			if err != nil {
 				errs <- Failure{Edition: edition, Error: err}
 				return
@@ -41,9 +41,9 @@ func createArcoLinuxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 			for f, match := range contents.FileMatches(isoRe) {
 				release := match[1]
 
-				var checksum string
-				if cf, ok := contents.Files[f.Name+".md5"]; ok {
-					checksum, err = cs.SingleWhitespace(cf)
+				checksum := f.Checksum()
+				if cf, ok := contents.Files[f.Name+".md5"]; ok && checksum == "" {
+					checksum, err = cs.SingleWhitespace(ctx, cf)
 					if err != nil {
 						csErrs <- Failure{Release: release, Edition: edition, Error: err}
 					}
@@ -53,7 +53,7 @@ func createArcoLinuxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 					Release: release,
 					Edition: edition,
 					ISO: []Source{
-						webSource(f.URL.String(), checksum, "", f.Name),
+						mirrorFileSource(f, checksum),
 					},
 				}
 			}
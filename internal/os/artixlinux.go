@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"regexp"
 
 	"github.com/quickemu-project/quickget_configs/internal/cs"
@@ -17,16 +18,16 @@ var ArtixLinux = OS{
 	ConfigFunction: createArtixLinuxConfigs,
 }
 
-func createArtixLinuxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createArtixLinuxConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	c := mirror.HttpClient{}
-	head, err := c.ReadDir(artixMirror)
+	head, err := c.ReadDir(ctx, artixMirror)
 	if err != nil {
 		return nil, err
 	}
 
 	checksums := make(map[string]string)
 	if f, ok := head.Files["sha256sums"]; ok {
-		checksums, err = cs.Build(cs.Whitespace, f)
+		checksums, err = cs.Build(ctx, cs.Whitespace, f)
 		if err != nil {
 			csErrs <- Failure{Error: err}
 		}
@@ -1,13 +1,14 @@
 package os
 
 import (
+	"context"
 	"strings"
 
 	"github.com/quickemu-project/quickget_configs/internal/cs"
-	"github.com/quickemu-project/quickget_configs/internal/web"
+	"github.com/quickemu-project/quickget_configs/internal/forge"
 )
 
-const athenaAPI = "https://api.github.com/repos/Athena-OS/athena/releases"
+const athenaRepo = "Athena-OS/athena"
 
 var AthenaOS = OS{
 	Name:           "athenaos",
@@ -17,50 +18,50 @@ var AthenaOS = OS{
 	ConfigFunction: createAthenaOSConfigs,
 }
 
-func createAthenaOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
-	var apiData []GithubAPI
-	if err := web.CapturePageToJson(athenaAPI, &apiData); err != nil {
-		return nil, err
-	}
+func createAthenaOSConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	ch, wg := getChannels()
+	found := 0
 
-	for i := 0; i < 2 && i < len(apiData); i++ {
-		data := apiData[i]
-		if data.Assets == nil {
-			continue
+	for data, err := range forge.GitHub.Releases(ctx, athenaRepo) {
+		if err != nil {
+			// Releases from earlier pages are still usable
+			if found == 0 {
+				return nil, err
+			}
+			errs <- Failure{Error: err}
+			break
 		}
+		if found >= 2 {
+			break
+		}
+		found++
 
-		release := data.TagName
+		release := data.Tag
 		if data.Prerelease {
 			release += "-pre"
 		}
 
-		var isoAsset *GithubAsset
-		for _, asset := range data.Assets {
-			if strings.HasSuffix(asset.Name, ".iso") {
-				isoAsset = &asset
-				break
-			}
-		}
+		isoAsset := data.FindAsset(func(name string) bool {
+			return strings.HasSuffix(name, ".iso")
+		})
 		if isoAsset == nil {
 			continue
 		}
-
-		checksumName := isoAsset.Name + ".sha256"
-		var checksumUrl string
-		for _, asset := range data.Assets {
-			if asset.Name == checksumName {
-				checksumUrl = asset.URL
-			}
-		}
+		checksumAsset := data.FindAsset(func(name string) bool {
+			return name == isoAsset.Name+".sha256"
+		})
 
 		wg.Go(func() {
-			var checksum string
-			if checksumUrl != "" {
-				var err error
-				checksum, err = cs.SingleWhitespace(checksumUrl)
+			checksum := isoAsset.Sha256()
+			if checksumAsset != nil {
+				published, err := cs.SingleWhitespace(ctx, checksumAsset.URL)
 				if err != nil {
 					csErrs <- Failure{Release: release, Error: err}
+				} else {
+					if err := cs.Verify(published, checksum); err != nil {
+						csErrs <- Failure{Release: release, Error: err}
+					}
+					checksum = published
 				}
 			}
 			ch <- Config{
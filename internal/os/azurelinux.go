@@ -1,6 +1,10 @@
 package os
 
-import "github.com/quickemu-project/quickget_configs/internal/cs"
+import (
+	"context"
+
+	"github.com/quickemu-project/quickget_configs/internal/cs"
+)
 
 var AzureLinux = OS{
 	Name:           "azurelinux",
@@ -10,7 +14,7 @@ var AzureLinux = OS{
 	ConfigFunction: createAzureLinuxConfigs,
 }
 
-func createAzureLinuxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createAzureLinuxConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	ch, wg := getChannels()
 	for _, arch := range x86_64_aarch64 {
 		wg.Go(func() {
@@ -18,7 +22,7 @@ func createAzureLinuxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 			url := urlBase + ".iso"
 
 			csUrl := urlBase + "-iso-checksum"
-			checksum, err := cs.SingleWhitespace(csUrl)
+			checksum, err := cs.SingleWhitespace(ctx, csUrl)
 			if err != nil {
 				csErrs <- Failure{Arch: arch, Error: err}
 			}
@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"regexp"
 
 	"github.com/quickemu-project/quickget_configs/internal/web"
@@ -20,8 +21,8 @@ var Batocera = OS{
 	ConfigFunction: createBatoceraConfigs,
 }
 
-func createBatoceraConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
-	releases, err := getSortedReleasesFunc(batoceraMirror, batoceraReleaseRe, 3, integerCompare)
+func createBatoceraConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+	releases, err := getSortedReleasesFunc(ctx, batoceraMirror, batoceraReleaseRe, 3, integerCompare)
 	if err != nil {
 		return nil, err
 	}
@@ -31,7 +32,7 @@ func createBatoceraConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 	for _, release := range releases {
 		url := batoceraMirror + release + "/"
 		wg.Go(func() {
-			page, err := web.CapturePage(url)
+			page, err := web.CapturePage(ctx, url)
 			if err != nil {
 				errs <- Failure{Release: release, Error: err}
 				return
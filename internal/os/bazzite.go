@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"regexp"
 	"slices"
 	"strings"
@@ -22,8 +23,8 @@ var Bazzite = OS{
 	ConfigFunction: createBazziteConfigs,
 }
 
-func createBazziteConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
-	page, err := web.CapturePage(bazziteWorkflow)
+func createBazziteConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+	page, err := web.CapturePage(ctx, bazziteWorkflow)
 	if err != nil {
 		return nil, err
 	}
@@ -45,7 +46,7 @@ func createBazziteConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 		}
 
 		wg.Go(func() {
-			checksum, err := cs.SingleWhitespace(url + "-CHECKSUM")
+			checksum, err := cs.SingleWhitespace(ctx, url+"-CHECKSUM")
 			if err != nil {
 				csErrs <- Failure{Release: release, Edition: edition, Error: err}
 			}
@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"regexp"
 
 	"github.com/quickemu-project/quickget_configs/internal/cs"
@@ -17,9 +18,9 @@ var BigLinux = OS{
 	ConfigFunction: createBigLinuxConfigs,
 }
 
-func createBigLinuxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createBigLinuxConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	c := mirror.HttpClient{}
-	head, err := c.ReadDir(biglinuxMirror)
+	head, err := c.ReadDir(ctx, biglinuxMirror)
 	if err != nil {
 		return nil, err
 	}
@@ -31,7 +32,7 @@ func createBigLinuxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 			release, edition := match[1], match[2]
 			var checksum string
 			if cf, ok := head.Files[f.Name+".md5"]; ok {
-				checksum, err = cs.SingleWhitespace(cf)
+				checksum, err = cs.SingleWhitespace(ctx, cf)
 				if err != nil {
 					csErrs <- Failure{Release: release, Edition: edition, Error: err}
 				}
@@ -1,5 +1,18 @@
 package os
 
+import (
+	"context"
+	"strings"
+
+	"github.com/quickemu-project/quickget_configs/internal/forge"
+)
+
+const (
+	blendosGitlab    = forge.GitLab("https://git.blendos.co")
+	blendosProject   = "32"
+	blendosLatestIso = "https://git.blendos.co/api/v4/projects/32/jobs/artifacts/main/raw/blendOS.iso?job=build-job"
+)
+
 var BlendOS = OS{
 	Name:           "blendos",
 	PrettyName:     "BlendOS",
@@ -8,11 +21,33 @@ var BlendOS = OS{
 	ConfigFunction: createBlendOSConfigs,
 }
 
-func createBlendOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createBlendOSConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+	for data, err := range blendosGitlab.Releases(ctx, blendosProject) {
+		if err != nil {
+			errs <- Failure{Error: err}
+			break
+		}
+		isoAsset := data.FindAsset(func(name string) bool {
+			return strings.HasSuffix(name, ".iso")
+		})
+		if isoAsset == nil {
+			continue
+		}
+		return []Config{
+			{
+				Release: data.Tag,
+				ISO: []Source{
+					webSource(isoAsset.URL, isoAsset.Sha256(), "", isoAsset.Name),
+				},
+			},
+		}, nil
+	}
+
+	// Fall back to the latest build of the main branch when no release has an ISO
 	return []Config{
 		{
 			ISO: []Source{
-				urlSource("https://git.blendos.co/api/v4/projects/32/jobs/artifacts/main/raw/blendOS.iso?job=build-job"),
+				urlSource(blendosLatestIso),
 			},
 		},
 	}, nil
@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"regexp"
 
 	"github.com/quickemu-project/quickget_configs/internal/cs"
@@ -21,8 +22,8 @@ var Bodhi = OS{
 	ConfigFunction: createBodhiConfigs,
 }
 
-func createBodhiConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
-	releases, _, err := getBasicReleases(bodhiMirror, bodhiReleaseRe, 3)
+func createBodhiConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+	releases, _, err := getBasicReleases(ctx, bodhiMirror, bodhiReleaseRe, 3)
 	if err != nil {
 		return nil, err
 	}
@@ -32,7 +33,7 @@ func createBodhiConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 	for release := range releases {
 		mirror := bodhiMirror + release + "/"
 		wg.Go(func() {
-			page, err := web.CapturePage(mirror)
+			page, err := web.CapturePage(ctx, mirror)
 			if err != nil {
 				errs <- Failure{Release: release, Error: err}
 				return
@@ -43,11 +44,11 @@ func createBodhiConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 				if match[2] != "" {
 					edition = match[2][1:]
 				}
-				url := mirror + match[1] + "/download"
-				checksumUrl := mirror + match[1] + ".sha256/download"
+				url := sourceforgeDirect(mirror + match[1] + "/download")
+				checksumUrl := sourceforgeDirect(mirror + match[1] + ".sha256/download")
 
 				wg.Go(func() {
-					checksum, err := cs.SingleWhitespace(checksumUrl)
+					checksum, err := cs.SingleWhitespace(ctx, checksumUrl)
 					if err != nil {
 						csErrs <- Failure{Release: release, Edition: edition, Error: err}
 					}
@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"maps"
 	"regexp"
 	"strings"
@@ -20,9 +21,9 @@ var BunsenLabs = OS{
 	ConfigFunction: createBunsenLabsConfigs,
 }
 
-func createBunsenLabsConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createBunsenLabsConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	c := mirror.LegacyHttpClient{}
-	head, err := c.ReadDir(bunsenLabsMirror)
+	head, err := c.ReadDir(ctx, bunsenLabsMirror)
 	if err != nil {
 		return nil, err
 	}
@@ -31,7 +32,7 @@ func createBunsenLabsConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 	checksums := make(map[string]string)
 	for k, f := range head.Files {
 		if strings.HasSuffix(k, "txt") && strings.Contains(k, "sum") {
-			partialChecksums, err := cs.Build(cs.Whitespace, f)
+			partialChecksums, err := cs.Build(ctx, cs.Whitespace, f)
 			if err != nil {
 				csErrs <- Failure{Error: err}
 			} else {
@@ -54,7 +55,7 @@ func createBunsenLabsConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 	return configs, nil
 }
 
-func getBunsenLabsChecksums(page string, csErrs chan<- Failure) map[string]string {
+func getBunsenLabsChecksums(ctx context.Context, page string, csErrs chan<- Failure) map[string]string {
 	checksumRe := regexp.MustCompile(`href="(.*?.sha256.txt)"`)
 	ch := make(chan map[string]string)
 	var wg sync.WaitGroup
@@ -63,7 +64,7 @@ func getBunsenLabsChecksums(page string, csErrs chan<- Failure) map[string]strin
 	for _, match := range matches {
 		url := bunsenLabsMirror + match[1]
 		wg.Go(func() {
-			checksums, err := cs.Build(cs.Whitespace, url)
+			checksums, err := cs.Build(ctx, cs.Whitespace, url)
 			if err != nil {
 				csErrs <- Failure{Error: err}
 			} else {
@@ -1,7 +1,7 @@
 package os
 
 import (
-	"errors"
+	"context"
 	"slices"
 	"strings"
 
@@ -20,9 +20,9 @@ var CachyOS = OS{
 	ConfigFunction: createCachyOSConfigs,
 }
 
-func createCachyOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createCachyOSConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	c := mirror.HttpClient{}
-	head, err := c.ReadDir(cachyOSMirror)
+	head, err := c.ReadDir(ctx, cachyOSMirror)
 	if err != nil {
 		return nil, err
 	}
@@ -30,7 +30,7 @@ func createCachyOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 
 	for edition, d := range head.SubDirs {
 		wg.Go(func() {
-			contents, err := d.Fetch()
+			contents, err := d.Fetch(ctx)
 			if err != nil {
 				errs <- Failure{Error: err}
 				return
@@ -38,7 +38,7 @@ func createCachyOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 			releases := contents.NameSortedSubDirs(utils.IntegerCompare)
 			for _, d := range slices.Backward(releases) {
 				release := "latest"
-				contents, err := d.Fetch()
+				contents, err := d.Fetch(ctx)
 				if err != nil {
 					errs <- Failure{Release: d.Name, Edition: edition, Error: err}
 					continue
@@ -49,13 +49,13 @@ func createCachyOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 				})
 
 				if !ok {
-					errs <- Failure{Release: d.Name, Edition: edition, Error: errors.New("could not find ISO in directory")}
+					errs <- Failure{Release: d.Name, Edition: edition, Error: noMatch("could not find ISO in directory")}
 					continue
 				}
 
 				var checksum string
 				if cf, ok := contents.Files[f.Name+".sha256"]; ok {
-					checksum, err = cs.SingleWhitespace(cf)
+					checksum, err = cs.SingleWhitespace(ctx, cf)
 					if err != nil {
 						csErrs <- Failure{Release: release, Edition: edition, Error: err}
 					}
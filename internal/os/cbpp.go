@@ -1,12 +1,14 @@
 package os
 
 import (
+	"context"
 	"strings"
 
-	"github.com/quickemu-project/quickget_configs/internal/web"
+	"github.com/quickemu-project/quickget_configs/internal/cs"
+	"github.com/quickemu-project/quickget_configs/internal/forge"
 )
 
-const cbppApi = "https://api.github.com/repos/CBPP/cbpp/releases"
+const cbppRepo = "CBPP/cbpp"
 
 var CBPP = OS{
 	Name:           "crunchbang++",
@@ -16,31 +18,38 @@ var CBPP = OS{
 	ConfigFunction: createCBPPConfigs,
 }
 
-func createCBPPConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
-	var apiData []GithubAPI
-	if err := web.CapturePageToJson(cbppApi, &apiData); err != nil {
-		return nil, err
-	}
+func createCBPPConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	configs := make([]Config, 0)
-	for i := 0; i < len(apiData) && i < 3; i++ {
-		data := apiData[i]
-		release := data.TagName
-
-		var isoAsset *GithubAsset
-		for _, asset := range data.Assets {
-			if strings.Contains(asset.Name, "amd64") {
-				isoAsset = &asset
-				break
+	found := 0
+	for data, err := range forge.GitHub.Releases(ctx, cbppRepo) {
+		if err != nil {
+			// Releases from earlier pages are still usable
+			if found == 0 {
+				return nil, err
 			}
+			errs <- Failure{Error: err}
+			break
+		}
+		if found >= 3 {
+			break
 		}
+		found++
+		release := data.Tag
+
+		isoAsset := data.FindAsset(func(name string) bool {
+			return strings.Contains(name, "amd64")
+		})
 		if isoAsset == nil {
 			continue
 		}
 
-		var checksum string
+		checksum := isoAsset.Sha256()
 		for line := range strings.Lines(data.Body) {
 			if strings.Contains(line, isoAsset.Name) {
 				checksum = strings.SplitN(line, " ", 2)[0]
+				if err := cs.Verify(checksum, isoAsset.Sha256()); err != nil {
+					csErrs <- Failure{Release: release, Error: err}
+				}
 				break
 			}
 		}
@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 
@@ -22,8 +23,8 @@ var CentOSStream = OS{
 	ConfigFunction: createCentOSStreamConfigs,
 }
 
-func createCentOSStreamConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
-	releases, _, err := getBasicReleases(centOSMirror, centOSReleaseRe, -1)
+func createCentOSStreamConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+	releases, _, err := getBasicReleases(ctx, centOSMirror, centOSReleaseRe, -1)
 	if err != nil {
 		return nil, err
 	}
@@ -35,12 +36,12 @@ func createCentOSStreamConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 			mirror := centOSMirror + mirrorAdd
 
 			wg.Go(func() {
-				page, err := web.CapturePage(mirror)
+				page, err := web.CapturePage(ctx, mirror)
 				if err != nil {
 					errs <- Failure{Release: release, Arch: arch, Error: err}
 					return
 				}
-				checksums, err := cs.Build(cs.Sha256Regex, mirror+"SHA256SUM")
+				checksums, err := cs.Build(ctx, cs.Sha256Regex, mirror+"SHA256SUM")
 				if err != nil {
 					csErrs <- Failure{Release: release, Arch: arch, Error: err}
 				}
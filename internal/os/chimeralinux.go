@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"regexp"
 
 	"github.com/quickemu-project/quickget_configs/internal/cs"
@@ -17,9 +18,9 @@ var ChimeraLinux = OS{
 	ConfigFunction: createChimeraLinuxConfigs,
 }
 
-func createChimeraLinuxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createChimeraLinuxConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	c := mirror.LegacyHttpClient{}
-	head, err := c.ReadDir(chimeraMirror)
+	head, err := c.ReadDir(ctx, chimeraMirror)
 	if err != nil {
 		return nil, err
 	}
@@ -27,7 +28,7 @@ func createChimeraLinuxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 
 	checksums := make(map[string]string)
 	if f, ok := head.Files["sha256sums.txt"]; ok {
-		checksums, err = cs.Build(cs.Whitespace, f)
+		checksums, err = cs.Build(ctx, cs.Whitespace, f)
 		if err != nil {
 			csErrs <- Failure{Release: "latest", Error: err}
 		}
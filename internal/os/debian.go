@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"fmt"
 	"maps"
 	"regexp"
@@ -12,19 +13,29 @@ import (
 	"github.com/hashicorp/go-version"
 	"github.com/quickemu-project/quickget_configs/internal/cs"
 	"github.com/quickemu-project/quickget_configs/internal/web"
+	"github.com/quickemu-project/quickget_configs/pkg/quickgetdata"
 )
 
 const (
-	latestDebianMirror = "https://cdimage.debian.org/debian-cd/"
-	prevDebianMirror   = "https://cdimage.debian.org/cdimage/archive/"
+	latestDebianMirror  = "https://cdimage.debian.org/debian-cd/"
+	prevDebianMirror    = "https://cdimage.debian.org/cdimage/archive/"
+	debianNetbootMirror = "https://deb.debian.org/debian/dists/"
+	debianCloudMirror   = "https://cloud.debian.org/images/cloud/"
+	// Matches the default entry of the installer's own PXE configuration
+	debianNetbootCmdline = "vga=788 --- quiet"
 )
 
 var (
-	debianReleaseRe = regexp.MustCompile(`href="([0-9.]+)/"`)
-	debianLiveRe    = regexp.MustCompile(`>(debian-live-[0-9.]+-amd64-([^.]+).iso)<`)
-	debianNetinstRe = regexp.MustCompile(`>(debian-[0-9].+-(?:amd64|arm64)-(netinst).iso)<`)
+	debianReleaseRe  = regexp.MustCompile(`href="([0-9.]+)/"`)
+	debianLiveRe     = regexp.MustCompile(`>(debian-live-[0-9.]+-amd64-([^.]+).iso)<`)
+	debianNetinstRe  = regexp.MustCompile(`>(debian-[0-9].+-[a-z0-9]+-(netinst).iso)<`)
+	debianCodenameRe = regexp.MustCompile(`href="([a-z]+)/"`)
+	debianCloudRe    = regexp.MustCompile(`href="(debian-(\d+)-(genericcloud|nocloud)-(amd64|arm64)\.qcow2)"`)
 )
 
+// Debian architecture names which may have netinst images
+var debianArchitectures = [...]string{"amd64", "arm64", "i386", "armhf", "ppc64el", "s390x"}
+
 var Debian = OS{
 	Name:           "debian",
 	PrettyName:     "Debian",
@@ -33,17 +44,18 @@ var Debian = OS{
 	ConfigFunction: createDebianConfigs,
 }
 
-func createDebianConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createDebianConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	ch, wg := getChannels()
 
-	latestRelease := getLatestDebianConfigs(ch, wg, errs, csErrs)
-	getOldDebianConfigs(ch, wg, errs, csErrs, latestRelease)
+	latestRelease := getLatestDebianConfigs(ctx, ch, wg, errs, csErrs)
+	getOldDebianConfigs(ctx, ch, wg, errs, csErrs, latestRelease)
+	addDebianCloudConfigs(ctx, ch, wg, errs, csErrs, latestRelease)
 
 	return waitForConfigs(ch, wg), nil
 }
 
-func getLatestDebianConfigs(ch chan Config, wg *sync.WaitGroup, errs, csErrs chan<- Failure) int {
-	page, err := web.CapturePage(latestDebianMirror)
+func getLatestDebianConfigs(ctx context.Context, ch chan Config, wg *sync.WaitGroup, errs, csErrs chan<- Failure) int {
+	page, err := web.CapturePage(ctx, latestDebianMirror)
 	if err != nil {
 		errs <- Failure{Error: err}
 		return 0
@@ -57,12 +69,13 @@ func getLatestDebianConfigs(ch chan Config, wg *sync.WaitGroup, errs, csErrs cha
 		errs <- Failure{Error: err}
 	}
 
-	addDebianConfigs(latestDebianMirror, release, fullRelease, ch, wg, errs, csErrs)
+	addDebianConfigs(ctx, latestDebianMirror, release, fullRelease, ch, wg, errs, csErrs)
+	addDebianNetbootConfigs(ctx, "stable", release, ch, wg, errs, csErrs)
 	return latestRelease
 }
 
-func getOldDebianConfigs(ch chan Config, wg *sync.WaitGroup, errs, csErrs chan<- Failure, latestRelease int) {
-	page, err := web.CapturePage(prevDebianMirror)
+func getOldDebianConfigs(ctx context.Context, ch chan Config, wg *sync.WaitGroup, errs, csErrs chan<- Failure, latestRelease int) {
+	page, err := web.CapturePage(ctx, prevDebianMirror)
 	if err != nil {
 		errs <- Failure{Error: err}
 		return
@@ -73,8 +86,10 @@ func getOldDebianConfigs(ch chan Config, wg *sync.WaitGroup, errs, csErrs chan<-
 	}
 
 	for release := latestRelease - 2; release < latestRelease; release++ {
-		addDebianConfigs(prevDebianMirror, strconv.Itoa(release), releaseMap[release], ch, wg, errs, csErrs)
+		addDebianConfigs(ctx, prevDebianMirror, strconv.Itoa(release), releaseMap[release], ch, wg, errs, csErrs)
 	}
+	// The installer for older releases is removed from the main archive
+	addDebianNetbootConfigs(ctx, "oldstable", strconv.Itoa(latestRelease-1), ch, wg, errs, csErrs)
 }
 
 func createReleaseMap(html string, errs chan<- Failure) map[int]string {
@@ -98,16 +113,16 @@ func createReleaseMap(html string, errs chan<- Failure) map[int]string {
 	return m
 }
 
-func addDebianConfigs(mirror, release, fullRelease string, ch chan Config, wg *sync.WaitGroup, errs, csErrs chan<- Failure) {
+func addDebianConfigs(ctx context.Context, mirror, release, fullRelease string, ch chan Config, wg *sync.WaitGroup, errs, csErrs chan<- Failure) {
 	liveMirror := mirror + fullRelease + "-live/amd64/iso-hybrid/"
 
 	wg.Go(func() {
-		page, err := web.CapturePage(liveMirror)
+		page, err := web.CapturePage(ctx, liveMirror)
 		if err != nil {
 			errs <- Failure{Release: release, Error: err}
 			return
 		}
-		checksums, err := cs.Build(cs.Whitespace, liveMirror+"SHA256SUMS")
+		checksums, err := cs.Build(ctx, cs.Whitespace, liveMirror+"SHA256SUMS")
 		if err != nil {
 			csErrs <- Failure{Release: release, Error: err}
 		}
@@ -125,34 +140,130 @@ func addDebianConfigs(mirror, release, fullRelease string, ch chan Config, wg *s
 		}
 	})
 
-	architectures := [2]string{"amd64", "arm64"}
-	for _, a := range architectures {
-		arch, _ := NewArch(a)
-		netInstMirror := fmt.Sprintf("%s%s/%s/iso-cd/", mirror, fullRelease, a)
+	releaseMirror := mirror + fullRelease + "/"
+	wg.Go(func() {
+		// Architectures are dropped between releases (e.g. i386 after bookworm), so only request those the mirror lists
+		page, err := web.CapturePage(ctx, releaseMirror)
+		if err != nil {
+			errs <- Failure{Release: release, Error: err}
+			return
+		}
+		for _, a := range debianArchitectures {
+			if !strings.Contains(page, `href="`+a+`/"`) {
+				continue
+			}
+			arch, _ := NewArch(a)
+			netInstMirror := fmt.Sprintf("%s%s/iso-cd/", releaseMirror, a)
+			wg.Go(func() {
+				page, err := web.CapturePage(ctx, netInstMirror)
+				if err != nil {
+					errs <- Failure{Release: release, Arch: arch, Error: err}
+					return
+				}
+				checksums, err := cs.Build(ctx, cs.Whitespace, netInstMirror+"SHA256SUMS")
+				if err != nil {
+					csErrs <- Failure{Release: release, Arch: arch, Error: err}
+				}
+
+				for _, match := range debianNetinstRe.FindAllStringSubmatch(page, -1) {
+					iso := match[1]
+					url := netInstMirror + iso
+					checksum := checksums[iso]
+					ch <- Config{
+						Release: release,
+						Edition: match[2],
+						Arch:    arch,
+						ISO: []Source{
+							urlChecksumSource(url, checksum),
+						},
+					}
+				}
+			})
+		}
+	})
+}
+
+func addDebianNetbootConfigs(ctx context.Context, suite, release string, ch chan Config, wg *sync.WaitGroup, errs, csErrs chan<- Failure) {
+	for _, arch := range x86_64_aarch64 {
+		a := "amd64"
+		if arch == aarch64 {
+			a = "arm64"
+		}
+		imagesMirror := fmt.Sprintf("%s%s/main/installer-%s/current/images/", debianNetbootMirror, suite, a)
+		wg.Go(func() {
+			checksums, err := cs.Build(ctx, cs.Whitespace, imagesMirror+"SHA256SUMS")
+			if err != nil {
+				csErrs <- Failure{Release: release, Edition: "netboot", Arch: arch, Error: err}
+			}
+			netbootDir := "netboot/debian-installer/" + a + "/"
+			kernel := netbootDir + "linux"
+			initrd := netbootDir + "initrd.gz"
+			ch <- Config{
+				Release: release,
+				Edition: "netboot",
+				Arch:    arch,
+				KernelBoot: []Source{
+					kernelSource(
+						WebSource{URL: imagesMirror + kernel, Checksum: checksums[kernel]},
+						[]WebSource{{URL: imagesMirror + initrd, Checksum: checksums[initrd]}},
+						debianNetbootCmdline,
+					),
+				},
+			}
+		})
+	}
+}
+
+// Cloud images are organised by codename rather than version, so every codename directory is read and the version taken from the image names
+func addDebianCloudConfigs(ctx context.Context, ch chan Config, wg *sync.WaitGroup, errs, csErrs chan<- Failure, latestRelease int) {
+	page, err := web.CapturePage(ctx, debianCloudMirror)
+	if err != nil {
+		errs <- Failure{Edition: "cloud", Error: err}
+		return
+	}
+	for _, match := range debianCodenameRe.FindAllStringSubmatch(page, -1) {
+		latestMirror := debianCloudMirror + match[1] + "/latest/"
 		wg.Go(func() {
-			page, err := web.CapturePage(netInstMirror)
+			page, err := web.CapturePage(ctx, latestMirror)
 			if err != nil {
-				errs <- Failure{Release: release, Error: err}
+				errs <- Failure{Edition: "cloud", Error: err}
+				return
+			}
+			matches := debianCloudRe.FindAllStringSubmatch(page, -1)
+			// Match the releases offered by the installer images
+			if len(matches) == 0 || isOldDebianRelease(matches[0][2], latestRelease) {
 				return
 			}
-			checksums, err := cs.Build(cs.Whitespace, netInstMirror+"SHA256SUMS")
+			checksums, err := cs.Build(ctx, cs.Whitespace, latestMirror+"SHA512SUMS")
 			if err != nil {
-				csErrs <- Failure{Release: release, Error: err}
+				csErrs <- Failure{Release: matches[0][2], Edition: "cloud", Error: err}
 			}
-
-			for _, match := range debianNetinstRe.FindAllStringSubmatch(page, -1) {
-				iso := match[1]
-				url := netInstMirror + iso
-				checksum := checksums[iso]
+			for _, match := range slices.CompactFunc(matches, func(a, b []string) bool { return a[1] == b[1] }) {
+				img, release, variant := match[1], match[2], match[3]
+				arch, _ := NewArch(match[4])
+				// The genericcloud image matches the cloud edition of other distros. The nocloud image allows passwordless root login instead of running cloud-init
+				edition := "cloud"
+				if variant != "genericcloud" {
+					edition = variant
+				}
 				ch <- Config{
 					Release: release,
-					Edition: match[2],
+					Edition: edition,
 					Arch:    arch,
-					ISO: []Source{
-						urlChecksumSource(url, checksum),
+					DiskImages: []Disk{
+						{
+							Source: webSource(latestMirror+img, checksums[img], "", img),
+							Format: quickgetdata.Qcow2,
+						},
 					},
+					CloudInit: variant == "genericcloud",
 				}
 			}
 		})
 	}
 }
+
+func isOldDebianRelease(release string, latestRelease int) bool {
+	r, err := strconv.Atoi(release)
+	return err == nil && latestRelease > 0 && r < latestRelease-2
+}
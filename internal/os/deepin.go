@@ -1,7 +1,7 @@
 package os
 
 import (
-	"errors"
+	"context"
 	"strings"
 
 	"github.com/quickemu-project/quickget_configs/internal/cs"
@@ -21,9 +21,9 @@ var Deepin = OS{
 	ConfigFunction: createDeepinConfigs,
 }
 
-func createDeepinConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createDeepinConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	c := mirror.HttpClient{}
-	head, err := c.ReadDir(deepinMirror)
+	head, err := c.ReadDir(ctx, deepinMirror)
 	if err != nil {
 		return nil, err
 	}
@@ -35,13 +35,13 @@ func createDeepinConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 	for _, d := range releases {
 		release := d.Name
 		wg.Go(func() {
-			contents, err := d.Fetch()
+			contents, err := d.Fetch(ctx)
 			if err != nil {
 				errs <- Failure{Release: release, Error: err}
 				return
 			}
 			if len(contents.Files) > 0 {
-				config, csErr, err := createDeepinConfig(contents, release, x86_64)
+				config, csErr, err := createDeepinConfig(ctx, contents, release, x86_64)
 				if err != nil {
 					errs <- Failure{Release: release, Error: err}
 				} else {
@@ -52,7 +52,7 @@ func createDeepinConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 				}
 			}
 			for a, d := range contents.SubDirs {
-				contents, err := d.Fetch()
+				contents, err := d.Fetch(ctx)
 				if err != nil {
 					errs <- Failure{Release: release, Error: err}
 					return
@@ -61,7 +61,7 @@ func createDeepinConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 				if !v {
 					continue
 				}
-				config, csErr, err := createDeepinConfig(contents, release, arch)
+				config, csErr, err := createDeepinConfig(ctx, contents, release, arch)
 				if err != nil {
 					errs <- Failure{Release: release, Error: err}
 				} else {
@@ -76,12 +76,12 @@ func createDeepinConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 	return waitForConfigs(ch, wg), nil
 }
 
-func createDeepinConfig(dir *mirror.Directory, release string, arch Arch) (config *Config, csErr error, err error) {
+func createDeepinConfig(ctx context.Context, dir *mirror.Directory, release string, arch Arch) (config *Config, csErr error, err error) {
 	for k, f := range dir.Files {
 		if strings.HasSuffix(k, ".iso") {
 			var checksum string
 			if f, ok := dir.Files["SHA256SUMS"]; ok {
-				checksum, csErr = cs.SingleWhitespace(f)
+				checksum, csErr = cs.SingleWhitespace(ctx, f)
 			}
 			config = &Config{
 				Release: release,
@@ -93,5 +93,5 @@ func createDeepinConfig(dir *mirror.Directory, release string, arch Arch) (confi
 			return
 		}
 	}
-	return nil, nil, errors.New("could not find ISO file in mirror")
+	return nil, nil, noMatch("could not find ISO file in mirror")
 }
@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"regexp"
 	"strings"
 
@@ -20,9 +21,9 @@ var Devuan = OS{
 	ConfigFunction: createDevuanConfigs,
 }
 
-func createDevuanConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createDevuanConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	c := mirror.LegacyHttpClient{}
-	head, err := c.ReadDir(devuanMirror)
+	head, err := c.ReadDir(ctx, devuanMirror)
 	if err != nil {
 		return nil, err
 	}
@@ -36,7 +37,7 @@ func createDevuanConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 		}
 		release := strings.TrimPrefix(releaseDir.Name, "devuan_")
 		wg.Go(func() {
-			contents, err := releaseDir.Fetch()
+			contents, err := releaseDir.Fetch(ctx)
 			if err != nil {
 				errs <- Failure{Release: release, Error: err}
 				return
@@ -44,7 +45,7 @@ func createDevuanConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 			// If there's a desktop live subdirectory we'll use it, as that's the standard directory
 			// structure as of now. Otherwise, just try with the main directory
 			if d, ok := contents.SubDirs["desktop-live"]; ok {
-				contents, err = d.Fetch()
+				contents, err = d.Fetch(ctx)
 				if err != nil {
 					errs <- Failure{Release: release, Error: err}
 					return
@@ -55,7 +56,7 @@ func createDevuanConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 			for k, f := range contents.Files {
 				k = strings.ToLower(k)
 				if strings.HasSuffix(k, "txt") && strings.Contains(k, "sum") {
-					checksums, err = cs.Build(cs.Whitespace, f)
+					checksums, err = cs.Build(ctx, cs.Whitespace, f)
 					if err != nil {
 						csErrs <- Failure{Release: release, Error: err}
 					} else {
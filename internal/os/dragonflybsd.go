@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"maps"
 	"regexp"
 	"slices"
@@ -26,9 +27,9 @@ type dragonflybsdRelease struct {
 	release string
 }
 
-func createDragonFlyBSDConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createDragonFlyBSDConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	c := mirror.LegacyHttpClient{}
-	head, err := c.ReadDir(dragonflybsdMirror)
+	head, err := c.ReadDir(ctx, dragonflybsdMirror)
 	if err != nil {
 		return nil, err
 	}
@@ -36,7 +37,7 @@ func createDragonFlyBSDConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 
 	checksums := make(map[string]string)
 	if f, ok := head.Files["md5.txt"]; ok {
-		checksums, err = cs.Build(cs.Md5Regex, f)
+		checksums, err = cs.Build(ctx, cs.Md5Regex, f)
 		if err != nil {
 			csErrs <- Failure{Error: err}
 		}
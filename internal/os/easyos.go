@@ -1,11 +1,9 @@
 package os
 
 import (
-	"errors"
-	"maps"
+	"context"
 	"slices"
 	"strings"
-	"sync"
 
 	"github.com/quickemu-project/quickget_configs/internal/cs"
 	"github.com/quickemu-project/quickget_configs/internal/mirror"
@@ -25,30 +23,25 @@ var EasyOS = OS{
 	ConfigFunction: createEasyOSConfigs,
 }
 
-func createEasyOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
-	c := mirror.HttpClient{}
-	releases, err := getEasyOSReleases(errs, c)
+func createEasyOSConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+	releases, err := getEasyOSReleases(ctx, errs)
 	if err != nil {
 		return nil, err
 	}
 
-	slices.SortFunc(releases, func(a, b mirror.SubDirEntry) int {
+	slices.SortFunc(releases, func(a, b *mirror.Directory) int {
 		return utils.SemverCompare(a.Name, b.Name)
 	})
 	releases = releases[max(len(releases)-3, 0):]
 
 	ch, wg := getChannels()
-	for _, d := range releases {
-		release := d.Name
+	for _, contents := range releases {
+		release := contents.Name
 		wg.Go(func() {
-			contents, err := d.Fetch()
-			if err != nil {
-				errs <- Failure{Release: release, Error: err}
-				return
-			}
 			var checksum string
+			var err error
 			if f, ok := contents.Files["md5sum.txt"]; ok {
-				checksum, err = cs.SingleWhitespace(f)
+				checksum, err = cs.SingleWhitespace(ctx, f)
 				if err != nil {
 					csErrs <- Failure{Release: release, Error: err}
 				}
@@ -59,7 +52,7 @@ func createEasyOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 			})
 
 			if !ok {
-				errs <- Failure{Release: release, Error: errors.New("could not find img file in mirror")}
+				errs <- Failure{Release: release, Error: noMatch("could not find img file in mirror")}
 				return
 			}
 
@@ -82,58 +75,46 @@ func createEasyOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 	return waitForConfigs(ch, wg), nil
 }
 
-func getEasyOSReleases(errs chan<- Failure, c mirror.Client) ([]mirror.SubDirEntry, error) {
-	contents, err := c.ReadDir(easyosMirror)
+// Finds the latest release of each series. The mirror is laid out as <series>/<year>/<release>/
+func getEasyOSReleases(ctx context.Context, errs chan<- Failure) ([]*mirror.Directory, error) {
+	contents, err := mirror.HttpClient{}.ReadDir(ctx, easyosMirror)
 	if err != nil {
 		return nil, err
 	}
 
-	var wg sync.WaitGroup
-	ch := make(chan mirror.SubDirEntry)
-	var releases []mirror.SubDirEntry
-	go func() {
-		wg.Wait()
-		close(ch)
-	}()
-
-	for _, d := range contents.SubDirs {
-		wg.Go(func() {
-			yearsDir, err := d.Fetch()
-			if err != nil {
-				errs <- Failure{Release: d.Name, Error: err}
-				return
+	var releases []*mirror.Directory
+	contents.Walk(ctx, func(p string, depth int, dir *mirror.Directory, err error) []mirror.SubDirEntry {
+		series, _, _ := strings.Cut(p, "/")
+		if err != nil {
+			errs <- Failure{Release: series, Error: err}
+			return nil
+		}
+		switch depth {
+		case 0:
+			return mirror.AllSubDirs(dir)
+		case 1:
+			if len(dir.SubDirs) == 0 {
+				errs <- Failure{Release: series, Error: noMatch("no years found in directory")}
+				return nil
 			}
-
-			years := slices.Collect(maps.Values(yearsDir.SubDirs))
-			if len(years) == 0 {
-				errs <- Failure{Release: d.Name, Error: errors.New("no years found in directory")}
-				return
-			}
-			latestYear := slices.MaxFunc(years, func(a, b mirror.SubDirEntry) int {
+			latestYear := slices.MaxFunc(mirror.AllSubDirs(dir), func(a, b mirror.SubDirEntry) int {
 				return strings.Compare(a.Name, b.Name)
 			})
-
-			releasesDir, err := latestYear.Fetch()
-			if err != nil {
-				errs <- Failure{Release: d.Name, Error: err}
-				return
+			return []mirror.SubDirEntry{latestYear}
+		case 2:
+			if len(dir.SubDirs) == 0 {
+				errs <- Failure{Release: series, Error: noMatch("no releases found in year directory")}
+				return nil
 			}
-
-			releases := slices.Collect(maps.Values(releasesDir.SubDirs))
-			if len(releases) == 0 {
-				errs <- Failure{Release: d.Name, Error: errors.New("no releases found in year directory")}
-				return
-			}
-			latestRelease := slices.MaxFunc(releases, func(a, b mirror.SubDirEntry) int {
+			latestRelease := slices.MaxFunc(mirror.AllSubDirs(dir), func(a, b mirror.SubDirEntry) int {
 				return utils.SemverCompare(a.Name, b.Name)
 			})
-			ch <- latestRelease
-		})
-	}
-
-	for release := range ch {
-		releases = append(releases, release)
-	}
+			return []mirror.SubDirEntry{latestRelease}
+		default:
+			releases = append(releases, dir)
+			return nil
+		}
+	})
 
 	return releases, nil
 }
@@ -1,7 +1,7 @@
 package os
 
 import (
-	"errors"
+	"context"
 	"regexp"
 
 	"github.com/quickemu-project/quickget_configs/internal/web"
@@ -20,8 +20,8 @@ var Elementary = OS{
 	ConfigFunction: createElementaryConfigs,
 }
 
-func createElementaryConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
-	page, err := web.CapturePage(elementaryUrl)
+func createElementaryConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+	page, err := web.CapturePage(ctx, elementaryUrl)
 	if err != nil {
 		return nil, err
 	}
@@ -29,18 +29,18 @@ func createElementaryConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 
 	downloadMatch := downloadRe.FindStringSubmatch(page)
 	if downloadMatch == nil {
-		return nil, errors.New("No download link found in HTML")
+		return nil, noMatch("No download link found in HTML")
 	}
 	url := "https:" + downloadMatch[1]
 
 	var checksum string
-	if csPage, err := web.CapturePage(elementaryChecksumUrl); err != nil {
+	if csPage, err := web.CapturePage(ctx, elementaryChecksumUrl); err != nil {
 		csErrs <- Failure{Error: err}
 	} else {
 		checksumRe := regexp.MustCompile(`"language-bash">([0-9a-f]{64})</code>`)
 		csMatch := checksumRe.FindStringSubmatch(csPage)
 		if csMatch == nil {
-			csErrs <- Failure{Error: errors.New("No checksum found in HTML")}
+			csErrs <- Failure{Error: missingChecksum("No checksum found in HTML")}
 		} else {
 			checksum = csMatch[1]
 		}
@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"regexp"
 	"strings"
 
@@ -18,9 +19,9 @@ var EndeavourOS = OS{
 	ConfigFunction: createEndeavourOSConfigs,
 }
 
-func createEndeavourOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createEndeavourOSConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	c := mirror.LegacyHttpClient{}
-	head, err := c.ReadDir(endeavourMirror)
+	head, err := c.ReadDir(ctx, endeavourMirror)
 	if err != nil {
 		return nil, err
 	}
@@ -35,7 +36,7 @@ func createEndeavourOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 			})
 			var checksum string
 			if ok {
-				checksum, err = cs.SingleWhitespace(cf)
+				checksum, err = cs.SingleWhitespace(ctx, cf)
 				if err != nil {
 					csErrs <- Failure{Release: release, Error: err}
 				}
@@ -1,7 +1,7 @@
 package os
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"regexp"
 
@@ -23,8 +23,8 @@ var EndlessOS = OS{
 	ConfigFunction: createEndlessOSConfigs,
 }
 
-func createEndlessOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
-	releases, _, err := getBasicReleases(endlessDataMirror, endlessReleaseRe, -1)
+func createEndlessOSConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+	releases, _, err := getBasicReleases(ctx, endlessDataMirror, endlessReleaseRe, -1)
 	if err != nil {
 		return nil, err
 	}
@@ -35,7 +35,7 @@ func createEndlessOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 	for release := range releases {
 		mirror := endlessDataMirror + release + "/eos-amd64-amd64/"
 		wg.Go(func() {
-			editions, err := getEndlessEditions(mirror, editionRe)
+			editions, err := getEndlessEditions(ctx, mirror, editionRe)
 			if err != nil {
 				errs <- Failure{Release: release, Error: err}
 				return
@@ -44,21 +44,21 @@ func createEndlessOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 			for _, edition := range editions {
 				mirror := mirror + edition + "/"
 				wg.Go(func() {
-					page, err := web.CapturePage(mirror)
+					page, err := web.CapturePage(ctx, mirror)
 					if err != nil {
 						errs <- Failure{Release: release, Edition: edition, Error: err}
 						return
 					}
 					isoMatch := isoRe.FindStringSubmatch(page)
 					if isoMatch == nil {
-						errs <- Failure{Release: release, Edition: edition, Error: errors.New("No ISO found")}
+						errs <- Failure{Release: release, Edition: edition, Error: noMatch("No ISO found")}
 						return
 					}
 					iso := isoMatch[1]
 					url := fmt.Sprintf("%s%s/eos-amd64-amd64/%s/%s", endlessDlMirror, release, edition, iso)
 
 					checksumUrl := url + ".sha256"
-					checksum, err := cs.SingleWhitespace(checksumUrl)
+					checksum, err := cs.SingleWhitespace(ctx, checksumUrl)
 					if err != nil {
 						csErrs <- Failure{Release: release, Edition: edition, Error: err}
 					}
@@ -77,8 +77,8 @@ func createEndlessOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 	return waitForConfigs(ch, wg), nil
 }
 
-func getEndlessEditions(url string, editionRe *regexp.Regexp) ([]string, error) {
-	page, err := web.CapturePage(url)
+func getEndlessEditions(ctx context.Context, url string, editionRe *regexp.Regexp) ([]string, error) {
+	page, err := web.CapturePage(ctx, url)
 	if err != nil {
 		return nil, err
 	}
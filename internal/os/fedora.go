@@ -1,15 +1,24 @@
 package os
 
 import (
+	"context"
+	"regexp"
 	"slices"
 	"strings"
 
+	"github.com/quickemu-project/quickget_configs/internal/cs"
 	"github.com/quickemu-project/quickget_configs/internal/web"
 	"github.com/quickemu-project/quickget_configs/pkg/quickgetdata"
 )
 
 const fedoraJsonUrl = "https://fedoraproject.org/releases.json"
 
+var fedoraTreeinfoRe = cs.CustomRegex{
+	Regex:      regexp.MustCompile(`(images/\S+) = sha256:([0-9a-f]{64})`),
+	KeyIndex:   1,
+	ValueIndex: 2,
+}
+
 var Fedora = OS{
 	Name:           "fedora",
 	PrettyName:     "Fedora",
@@ -18,12 +27,13 @@ var Fedora = OS{
 	ConfigFunction: createFedoraConfigs,
 }
 
-func createFedoraConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
-	releaseData, err := getFedoraReleases()
+func createFedoraConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+	releaseData, err := getFedoraReleases(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	ch, wg := getChannels()
 	configs := make([]Config, len(releaseData))
 	for i, r := range releaseData {
 		source := webSource(r.URL, r.Sha256, r.ArchiveFormat, "")
@@ -32,7 +42,15 @@ func createFedoraConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 			Edition: r.Edition,
 			Arch:    r.Arch,
 		}
-		if len(r.ArchiveFormat) == 0 {
+		if strings.HasSuffix(r.URL, ".qcow2") {
+			config.DiskImages = []Disk{
+				{
+					Source: source,
+					Format: quickgetdata.Qcow2,
+				},
+			}
+			config.CloudInit = true
+		} else if len(r.ArchiveFormat) == 0 {
 			config.ISO = []Source{source}
 		} else {
 			config.DiskImages = []Disk{
@@ -43,20 +61,57 @@ func createFedoraConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 			}
 		}
 		configs[i] = config
+
+		// The Everything netinstall ISO is published alongside an installation tree, which includes PXE boot images.
+		// Only Everything has one, so the config uses the plain "netboot" edition like other distros
+		if r.Edition == "Everything" && strings.Contains(r.URL, "/iso/") {
+			wg.Go(func() {
+				config, csErr := getFedoraNetbootConfig(ctx, r)
+				if csErr != nil {
+					csErrs <- Failure{Release: config.Release, Edition: config.Edition, Arch: config.Arch, Error: csErr}
+				}
+				ch <- config
+			})
+		}
 	}
 
-	return configs, nil
+	return append(configs, waitForConfigs(ch, wg)...), nil
+}
+
+func getFedoraNetbootConfig(ctx context.Context, r fedoraRelease) (Config, error) {
+	treeUrl := r.URL[:strings.LastIndex(r.URL, "/iso/")] + "/os/"
+	checksums, err := cs.Build(ctx, fedoraTreeinfoRe, treeUrl+".treeinfo")
+
+	kernel := "images/pxeboot/vmlinuz"
+	initrd := "images/pxeboot/initrd.img"
+	return Config{
+		Release: r.Release,
+		Edition: "netboot",
+		Arch:    r.Arch,
+		KernelBoot: []Source{
+			kernelSource(
+				WebSource{URL: treeUrl + kernel, Checksum: checksums[kernel]},
+				[]WebSource{{URL: treeUrl + initrd, Checksum: checksums[initrd]}},
+				"inst.repo="+treeUrl,
+			),
+		},
+	}, err
 }
 
-func getFedoraReleases() ([]fedoraRelease, error) {
+func getFedoraReleases(ctx context.Context) ([]fedoraRelease, error) {
 	var releaseData []fedoraRelease
-	if err := web.CapturePageToJson(fedoraJsonUrl, &releaseData); err != nil {
+	if err := web.CapturePageToJson(ctx, fedoraJsonUrl, &releaseData); err != nil {
 		return nil, err
 	}
 
 	validFedoraFiletypes := []string{"raw.xz", "iso"}
-	blacklistedEditions := []string{"Server", "Cloud_Base"}
+	// Cloud images are also published in formats other VM platforms use, only the qcow2 image is useful to us
+	validCloudFiletypes := []string{"qcow2"}
+	blacklistedEditions := []string{"Server"}
 	releaseData = slices.DeleteFunc(releaseData, func(r fedoraRelease) bool {
+		if r.Edition == "Cloud_Base" {
+			return !isValidFiletype(r.URL, validCloudFiletypes)
+		}
 		return isExcludedEdition(r.Edition, blacklistedEditions) ||
 			!isValidFiletype(r.URL, validFedoraFiletypes)
 	})
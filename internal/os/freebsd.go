@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"sync"
@@ -13,6 +14,8 @@ const (
 	freebsdX86Mirror     = "https://download.freebsd.org/ftp/releases/amd64/amd64/"
 	freebsdAarch64Mirror = "https://download.freebsd.org/ftp/releases/arm64/aarch64/"
 	freebsdRiscv64Mirror = "https://download.freebsd.org/ftp/releases/riscv/riscv64/"
+	freebsdI386Mirror    = "https://download.freebsd.org/ftp/releases/i386/i386/"
+	freebsdPpc64leMirror = "https://download.freebsd.org/ftp/releases/powerpc/powerpc64le/"
 )
 
 var FreeBSD = OS{
@@ -23,24 +26,30 @@ var FreeBSD = OS{
 	ConfigFunction: createFreeBSDConfigs,
 }
 
-func createFreeBSDConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createFreeBSDConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	ch, wg := getChannels()
 	releaseRe := regexp.MustCompile(`href="([0-9\.]+)-RELEASE`)
 	wg.Go(func() {
-		buildFreeBSDConfigs(freebsdX86Mirror, "amd64", x86_64, ch, wg, errs, csErrs, releaseRe)
+		buildFreeBSDConfigs(ctx, freebsdX86Mirror, "amd64", x86_64, ch, wg, errs, csErrs, releaseRe)
 	})
 	wg.Go(func() {
-		buildFreeBSDConfigs(freebsdAarch64Mirror, "arm64-aarch64", aarch64, ch, wg, errs, csErrs, releaseRe)
+		buildFreeBSDConfigs(ctx, freebsdAarch64Mirror, "arm64-aarch64", aarch64, ch, wg, errs, csErrs, releaseRe)
 	})
 	wg.Go(func() {
-		buildFreeBSDConfigs(freebsdRiscv64Mirror, "riscv-riscv64", riscv64, ch, wg, errs, csErrs, releaseRe)
+		buildFreeBSDConfigs(ctx, freebsdRiscv64Mirror, "riscv-riscv64", riscv64, ch, wg, errs, csErrs, releaseRe)
+	})
+	wg.Go(func() {
+		buildFreeBSDConfigs(ctx, freebsdI386Mirror, "i386", i686, ch, wg, errs, csErrs, releaseRe)
+	})
+	wg.Go(func() {
+		buildFreeBSDConfigs(ctx, freebsdPpc64leMirror, "powerpc-powerpc64le", ppc64le, ch, wg, errs, csErrs, releaseRe)
 	})
 
 	return waitForConfigs(ch, wg), nil
 }
 
-func buildFreeBSDConfigs(url, denom string, arch Arch, ch chan Config, wg *sync.WaitGroup, errs, csErrs chan<- Failure, releaseRe *regexp.Regexp) {
-	releases, _, err := getBasicReleases(url, releaseRe, -1)
+func buildFreeBSDConfigs(ctx context.Context, url, denom string, arch Arch, ch chan Config, wg *sync.WaitGroup, errs, csErrs chan<- Failure, releaseRe *regexp.Regexp) {
+	releases, _, err := getBasicReleases(ctx, url, releaseRe, -1)
 	if err != nil {
 		errs <- Failure{Error: err}
 		return
@@ -50,7 +59,7 @@ func buildFreeBSDConfigs(url, denom string, arch Arch, ch chan Config, wg *sync.
 	for release := range releases {
 		wg.Go(func() {
 			checksumUrl := fmt.Sprintf("%sISO-IMAGES/%s/CHECKSUM.SHA256-FreeBSD-%s-RELEASE-%s", url, release, release, denom)
-			checksums, err := cs.Build(cs.Sha256Regex, checksumUrl)
+			checksums, err := cs.Build(ctx, cs.Sha256Regex, checksumUrl)
 			if err != nil {
 				csErrs <- Failure{Error: err}
 			}
@@ -70,16 +79,22 @@ func buildFreeBSDConfigs(url, denom string, arch Arch, ch chan Config, wg *sync.
 			}
 		})
 
-		// VM Images (qcow2)
+		// VM Images (qcow2). These aren't built for POWER
+		if arch == ppc64le {
+			continue
+		}
 		wg.Go(func() {
 			mirrorArch := arch
-			if arch == x86_64 {
+			switch arch {
+			case x86_64:
 				mirrorArch = "amd64"
+			case i686:
+				mirrorArch = "i386"
 			}
 			mirror := fmt.Sprintf("https://download.freebsd.org/ftp/releases/VM-IMAGES/%s-RELEASE/%s/Latest/", release, mirrorArch)
 			iso := fmt.Sprintf("FreeBSD-%s-RELEASE-%s.qcow2.xz", release, denom)
 			checksumUrl := mirror + "CHECKSUM.SHA256"
-			checksums, err := cs.Build(cs.Sha256Regex, checksumUrl)
+			checksums, err := cs.Build(ctx, cs.Sha256Regex, checksumUrl)
 			if err != nil {
 				csErrs <- Failure{Error: err}
 			}
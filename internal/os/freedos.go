@@ -1,7 +1,7 @@
 package os
 
 import (
-	"errors"
+	"context"
 	"regexp"
 	"slices"
 	"strings"
@@ -12,10 +12,7 @@ import (
 	"github.com/quickemu-project/quickget_configs/pkg/quickgetdata"
 )
 
-const (
-	freedosMirror    = "https://www.ibiblio.org/pub/micro/pc-stuff/freedos/files/distributions/"
-	freedosReleaseRe = `href="(\d+\.\d+)/"`
-)
+const freedosMirror = "https://www.ibiblio.org/pub/micro/pc-stuff/freedos/files/distributions/"
 
 var FreeDOS = OS{
 	Name:           "freedos",
@@ -25,48 +22,55 @@ var FreeDOS = OS{
 	ConfigFunction: createFreeDOSConfigs,
 }
 
-func createFreeDOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createFreeDOSConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	c := mirror.HttpClient{}
-	head, err := c.ReadDir(freedosMirror)
+	head, err := c.ReadDir(ctx, freedosMirror)
 	if err != nil {
 		return nil, err
 	}
 	ch, wg := getChannels()
 	isoRe := regexp.MustCompile(`^FD\d+-?(.*?CD)\.(iso|zip)$`)
 
-	for release, d := range head.SubDirs {
-		wg.Go(func() {
-			contents, err := d.Fetch()
-			if err != nil {
-				errs <- Failure{Release: release, Error: err}
-				return
-			}
+	head.Walk(ctx, func(p string, depth int, contents *mirror.Directory, err error) []mirror.SubDirEntry {
+		release, _, _ := strings.Cut(p, "/")
+		if err != nil {
+			errs <- Failure{Release: release, Error: err}
+			return nil
+		}
+		switch depth {
+		case 0:
+			return mirror.AllSubDirs(contents)
+		case 1:
 			// FreeDOS releases prior to 1.4 have an "official" subdirectory which must be used.
 			// With 1.4, the main directory for the release is used. Handle both cases
 			if od, ok := contents.SubDirs["official"]; ok {
-				contents, err = od.Fetch()
-				if err != nil {
-					errs <- Failure{Release: release, Error: err}
-					return
-				}
+				return []mirror.SubDirEntry{od}
 			}
+		}
 
+		wg.Go(func() {
 			checksums := make(map[string]string)
 			for k, f := range contents.Files {
 				if k == "verify.txt" {
-					contents, err := web.CapturePage(f.URL)
+					page, err := web.CapturePage(ctx, f.URL)
 					if err != nil {
 						csErrs <- Failure{Release: release, Error: err}
+						break
 					}
-					lines := strings.Split(contents, "\n")
+					lines := strings.Split(page, "\n")
 					start, end := slices.Index(lines, "sha256sum:"), slices.Index(lines, "sha512sum:")
-
+					if start == -1 || end < start {
+						csErrs <- Failure{Release: release, Error: noMatch("Could not find SHA256 checksums in verify.txt")}
+						break
+					}
 					checksums = cs.Whitespace.BuildWithData(strings.Join(lines[start:end], "\n"))
 				} else if strings.HasSuffix(k, ".sha") {
-					checksums, err = cs.Build(cs.Whitespace, f)
+					data, err := cs.Build(ctx, cs.Whitespace, f)
 					if err != nil {
 						csErrs <- Failure{Release: release, Error: err}
+						break
 					}
+					checksums = data
 				} else {
 					continue
 				}
@@ -74,7 +78,11 @@ func createFreeDOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 			}
 
 			for f, match := range contents.FileMatches(isoRe) {
-				checksum := checksums[f.Name]
+				checksum, err := cs.Lookup(checksums, f.Name)
+				// An unavailable checksum file has already been reported
+				if err != nil && len(checksums) > 0 {
+					csErrs <- Failure{Release: release, Edition: match[1], Error: err}
+				}
 
 				var archiveFormat ArchiveFormat
 				if match[2] == "zip" {
@@ -91,14 +99,15 @@ func createFreeDOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 				}
 			}
 		})
-	}
+		return nil
+	})
 	return waitForConfigs(ch, wg), nil
 }
 
-func getFreeDOSChecksums(url, page string, checksumRe *regexp.Regexp) (map[string]string, error) {
+func getFreeDOSChecksums(ctx context.Context, url, page string, checksumRe *regexp.Regexp) (map[string]string, error) {
 	csUrlMatch := checksumRe.FindString(page)
 	if csUrlMatch == "" {
-		return nil, errors.New("Could not find Checksum URL")
+		return nil, noMatch("Could not find Checksum URL")
 	}
-	return cs.Build(cs.Whitespace, url+csUrlMatch)
+	return cs.Build(ctx, cs.Whitespace, url+csUrlMatch)
 }
@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"strings"
 
 	"github.com/quickemu-project/quickget_configs/internal/cs"
@@ -20,9 +21,9 @@ var Garuda = OS{
 	ConfigFunction: createGarudaConfigs,
 }
 
-func createGarudaConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createGarudaConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	c := mirror.HttpClient{}
-	head, err := c.ReadDir(garudaMirror)
+	head, err := c.ReadDir(ctx, garudaMirror)
 	if err != nil {
 		return nil, err
 	}
@@ -31,7 +32,7 @@ func createGarudaConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 	release := "latest"
 	for edition, d := range head.SubDirs {
 		wg.Go(func() {
-			contents, err := d.Fetch()
+			contents, err := d.Fetch(ctx)
 			if err != nil {
 				errs <- Failure{Release: release, Edition: edition, Error: err}
 				return
@@ -41,7 +42,7 @@ func createGarudaConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 				if strings.HasSuffix(k, "iso") {
 					var checksum string
 					if cf, ok := contents.Files[k+".sha256"]; ok {
-						checksum, err = cs.SingleWhitespace(cf)
+						checksum, err = cs.SingleWhitespace(ctx, cf)
 						if err != nil {
 							csErrs <- Failure{Release: release, Edition: edition, Error: err}
 						}
@@ -1,10 +1,11 @@
 package os
 
 import (
-	"regexp"
+	"context"
 	"strings"
 
 	"github.com/quickemu-project/quickget_configs/internal/cs"
+	"github.com/quickemu-project/quickget_configs/internal/mirror"
 	"github.com/quickemu-project/quickget_configs/internal/web"
 )
 
@@ -18,51 +19,54 @@ var Gentoo = OS{
 	ConfigFunction: createGentooConfigs,
 }
 
-func createGentooConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
-	architectures := [...]string{"amd64", "arm64"}
-	isoRe := regexp.MustCompile(`\d{8}T\d{6}Z\/(admincd|install|livegui).*?.iso`)
+func createGentooConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+	architectures := [...]string{"amd64", "arm64", "x86"}
 	ch, wg := getChannels()
 
 	release := "latest"
-	for _, arch := range architectures {
-		mirror := gentooMirror + arch + "/autobuilds/"
+	for _, a := range architectures {
+		arch, _ := NewArch(a)
 		wg.Go(func() {
-			page, err := web.CapturePage(mirror + "latest-iso.txt")
+			autobuilds, err := mirror.AutoClient{}.ReadDir(ctx, gentooMirror+a+"/autobuilds/")
 			if err != nil {
-				errs <- Failure{Release: release, Arch: Arch(arch), Error: err}
+				errs <- Failure{Release: release, Arch: arch, Error: err}
 				return
 			}
-			matches := isoRe.FindAllStringSubmatch(page, -1)
+			// Each current-* directory holds the latest build of an image
+			matches, err := autobuilds.Glob(ctx, "current-{admincd,install,livegui}-*/*.iso")
+			if err != nil {
+				errs <- Failure{Release: release, Arch: arch, Error: err}
+			}
 			for _, match := range matches {
-				edition := match[1]
+				edition := match.Captures[0]
 				if edition == "install" {
 					edition = "minimal"
 				}
-				url := mirror + match[0]
-				checksumUrl := url + ".sha256"
-
 				wg.Go(func() {
-					checksumPage, err := web.CapturePage(checksumUrl)
-					if err != nil {
-						csErrs <- Failure{Release: release, Edition: edition, Arch: Arch(arch), Error: err}
-					}
 					var checksum string
-					for _, line := range strings.Split(checksumPage, "\n") {
-						if strings.Contains(line, "iso") {
-							cs, err := cs.BuildSingleWhitespace(line)
-							if err != nil {
-								csErrs <- Failure{Release: release, Edition: edition, Arch: Arch(arch), Error: err}
+					if f, ok := match.Dir.Files[match.File.Name+".sha256"]; ok {
+						checksumPage, err := web.CapturePage(ctx, f.URL)
+						if err != nil {
+							csErrs <- Failure{Release: release, Edition: edition, Arch: arch, Error: err}
+						}
+						for _, line := range strings.Split(checksumPage, "\n") {
+							if strings.Contains(line, "iso") {
+								cs, err := cs.BuildSingleWhitespace(line)
+								if err != nil {
+									csErrs <- Failure{Release: release, Edition: edition, Arch: arch, Error: err}
+								}
+								checksum = cs
+								break
 							}
-							checksum = cs
-							break
 						}
 					}
 
 					ch <- Config{
 						Release: release,
 						Edition: edition,
+						Arch:    arch,
 						ISO: []Source{
-							urlChecksumSource(url, checksum),
+							urlChecksumSource(match.File.URL.String(), checksum),
 						},
 					}
 				})
@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"regexp"
 	"strings"
 
@@ -21,8 +22,8 @@ var GhostBSD = OS{
 	ConfigFunction: createGhostBSDConfigs,
 }
 
-func createGhostBSDConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
-	releases, numReleases, err := getReverseReleases(ghostbsdMirror, ghostbsdReleaseRe, 4)
+func createGhostBSDConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+	releases, numReleases, err := getReverseReleases(ctx, ghostbsdMirror, ghostbsdReleaseRe, 4)
 	if err != nil {
 		return nil, err
 	}
@@ -33,7 +34,7 @@ func createGhostBSDConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 		mirror := ghostbsdMirror + release + "/"
 		go func() {
 			defer wg.Done()
-			page, err := web.CapturePage(mirror)
+			page, err := web.CapturePage(ctx, mirror)
 			if err != nil {
 				errs <- Failure{Release: release, Error: err}
 				return
@@ -51,7 +52,7 @@ func createGhostBSDConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 				checksumUrl := url + ".sha256"
 
 				wg.Go(func() {
-					checksum, err := web.CapturePage(checksumUrl)
+					checksum, err := web.CapturePage(ctx, checksumUrl)
 					if err != nil {
 						csErrs <- Failure{Release: release, Edition: edition, Error: err}
 					}
@@ -1,7 +1,7 @@
 package os
 
 import (
-	"errors"
+	"context"
 	"strings"
 
 	"github.com/quickemu-project/quickget_configs/internal/mirror"
@@ -19,9 +19,9 @@ var GnomeOS = OS{
 	ConfigFunction: createGnomeOSConfigs,
 }
 
-func createGnomeOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createGnomeOSConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	c := mirror.HttpClient{}
-	head, err := c.ReadDir(gnomeosMirror)
+	head, err := c.ReadDir(ctx, gnomeosMirror)
 	if err != nil {
 		return nil, err
 	}
@@ -33,7 +33,7 @@ func createGnomeOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 	for _, d := range releases {
 		release := d.Name
 		wg.Go(func() {
-			contents, err := d.Fetch()
+			contents, err := d.Fetch(ctx)
 			if err != nil {
 				errs <- Failure{Release: release, Error: err}
 				return
@@ -42,7 +42,7 @@ func createGnomeOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 				return strings.HasSuffix(f.Name, ".iso")
 			})
 			if !ok {
-				errs <- Failure{Release: release, Error: errors.New("no ISO found")}
+				errs <- Failure{Release: release, Error: noMatch("no ISO found")}
 				return
 			}
 			ch <- Config{
@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"regexp"
 
 	"github.com/quickemu-project/quickget_configs/internal/mirror"
@@ -21,9 +22,9 @@ var Guix = OS{
 	ConfigFunction: createGuixConfigs,
 }
 
-func createGuixConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createGuixConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	c := mirror.HttpClient{}
-	head, err := c.ReadDir(guixDataMirror)
+	head, err := c.ReadDir(ctx, guixDataMirror)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/quickemu-project/quickget_configs/internal/cs"
@@ -20,8 +21,8 @@ var Haiku = OS{
 	ConfigFunction: createHaikuConfigs,
 }
 
-func createHaikuConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
-	releases, numReleases, err := getReverseReleases(haikuMirror, haikuReleaseRe, 3)
+func createHaikuConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+	releases, numReleases, err := getReverseReleases(ctx, haikuMirror, haikuReleaseRe, 3)
 	if err != nil {
 		return nil, err
 	}
@@ -33,7 +34,7 @@ func createHaikuConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 		go func() {
 			defer wg.Done()
 			url := mirror + iso
-			checksums, err := cs.Build(cs.Sha256Regex, url+".sha256")
+			checksums, err := cs.Build(ctx, cs.Sha256Regex, url+".sha256")
 			if err != nil {
 				csErrs <- Failure{Release: release, Error: err}
 			}
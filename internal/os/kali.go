@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"regexp"
 	"time"
 
@@ -23,9 +24,9 @@ type kaliMatch struct {
 	file         mirror.File
 }
 
-func createKaliConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createKaliConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	c := mirror.HttpClient{}
-	head, err := c.ReadDir(kaliMirror)
+	head, err := c.ReadDir(ctx, kaliMirror)
 	if err != nil {
 		return nil, err
 	}
@@ -40,7 +41,7 @@ func createKaliConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 			continue
 		}
 		wg.Go(func() {
-			contents, err := releaseDir.Fetch()
+			contents, err := releaseDir.Fetch(ctx)
 			if err != nil {
 				errs <- Failure{Release: release, Error: err}
 				return
@@ -48,7 +49,7 @@ func createKaliConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 
 			checksums := make(map[string]string)
 			if f, ok := contents.Files["SHA256SUMS"]; ok {
-				checksums, err = cs.Build(cs.Whitespace, f)
+				checksums, err = cs.Build(ctx, cs.Whitespace, f)
 				if err != nil {
 					csErrs <- Failure{Release: release, Error: err}
 				}
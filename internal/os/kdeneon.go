@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/quickemu-project/quickget_configs/internal/cs"
@@ -16,7 +17,7 @@ var KDENeon = OS{
 	ConfigFunction: createKdeNeonConfigs,
 }
 
-func createKdeNeonConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createKdeNeonConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	releases := [...]string{"user", "testing", "unstable", "developer"}
 	ch, wg := getChannelsWith(len(releases))
 	for _, release := range releases {
@@ -31,7 +32,7 @@ func createKdeNeonConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 
 		go func() {
 			defer wg.Done()
-			checksum, err := cs.SingleWhitespace(checksumUrl)
+			checksum, err := cs.SingleWhitespace(ctx, checksumUrl)
 			if err != nil {
 				csErrs <- Failure{Release: release, Error: err}
 			}
@@ -1,7 +1,7 @@
 package os
 
 import (
-	"errors"
+	"context"
 	"strings"
 
 	"github.com/quickemu-project/quickget_configs/internal/cs"
@@ -22,9 +22,9 @@ var KolibriOS = OS{
 	ConfigFunction: createKolibriOSConfigs,
 }
 
-func createKolibriOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createKolibriOSConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	c := mirror.HttpClient{}
-	head, err := c.ReadDir(kolibriMirror)
+	head, err := c.ReadDir(ctx, kolibriMirror)
 	if err != nil {
 		return nil, err
 	}
@@ -33,7 +33,7 @@ func createKolibriOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 	release := "latest"
 	for edition, d := range head.SubDirs {
 		wg.Go(func() {
-			contents, err := d.Fetch()
+			contents, err := d.Fetch(ctx)
 			if err != nil {
 				errs <- Failure{Release: release, Edition: edition, Error: err}
 				return
@@ -41,7 +41,7 @@ func createKolibriOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 
 			checksums := make(map[string]string)
 			if cf, ok := contents.Files["sha256sums.txt"]; ok {
-				checksums, err = cs.Build(cs.Whitespace, cf)
+				checksums, err = cs.Build(ctx, cs.Whitespace, cf)
 				if err != nil {
 					csErrs <- Failure{Release: release, Edition: edition, Error: err}
 				}
@@ -59,7 +59,7 @@ func createKolibriOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 
 			f, ok := contents.Files[filename]
 			if !ok {
-				errs <- Failure{Release: release, Edition: edition, Error: errors.New("named iso could not be found in mirror")}
+				errs <- Failure{Release: release, Edition: edition, Error: noMatch("named iso could not be found in mirror")}
 				return
 			}
 
@@ -1,7 +1,7 @@
 package os
 
 import (
-	"errors"
+	"context"
 	"strings"
 
 	"github.com/quickemu-project/quickget_configs/internal/cs"
@@ -21,9 +21,9 @@ var LinuxLite = OS{
 	ConfigFunction: createLinuxLiteConfigs,
 }
 
-func createLinuxLiteConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
-	c := mirror.SourceForgeClient{}
-	head, err := c.ReadDir(linuxliteMirror)
+func createLinuxLiteConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+	c := sourceforgeClient
+	head, err := c.ReadDir(ctx, linuxliteMirror)
 	if err != nil {
 		return nil, err
 	}
@@ -33,17 +33,18 @@ func createLinuxLiteConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 	releases = releases[max(len(releases)-5, 0):]
 
 	addConfig := func(release string, d *mirror.Directory, f mirror.File) {
-		var checksum string
+		checksum := f.Checksum()
 		if cf, ok := d.Files[f.Name+".sha256"]; ok {
-			checksum, err = cs.SingleWhitespace(cf)
-			if err != nil {
+			if sum, err := cs.SingleWhitespace(ctx, cf); err != nil {
 				csErrs <- Failure{Release: release, Error: err}
+			} else {
+				checksum = sum
 			}
 		}
 		ch <- Config{
 			Release: release,
 			ISO: []Source{
-				webSource(f.URL.String(), checksum, "", f.Name),
+				mirrorFileSource(f, checksum),
 			},
 		}
 	}
@@ -51,7 +52,7 @@ func createLinuxLiteConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 	for _, d := range releases {
 		release := d.Name
 		wg.Go(func() {
-			contents, err := d.Fetch()
+			contents, err := d.Fetch(ctx)
 			if err != nil {
 				errs <- Failure{Release: release, Error: err}
 				return
@@ -67,13 +68,13 @@ func createLinuxLiteConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 			// If only release candidate versions are available, we'll check those instead. We've already returned if there's a main release
 			rcs := contents.ModifiedTimeSortedSubdirs()
 			if len(rcs) == 0 {
-				errs <- Failure{Release: release, Error: errors.New("no iso present in dir")}
+				errs <- Failure{Release: release, Error: noMatch("no iso present in dir")}
 				return
 			}
 			rc := rcs[len(rcs)-1]
 			release += "-" + rc.Name
 
-			contents, err = rc.Fetch()
+			contents, err = rc.Fetch(ctx)
 			if err != nil {
 				errs <- Failure{Release: release, Error: err}
 				return
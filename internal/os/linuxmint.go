@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"iter"
 	"regexp"
 	"strings"
@@ -20,9 +21,9 @@ var LinuxMint = OS{
 	ConfigFunction: createLinuxMintConfigs,
 }
 
-func createLinuxMintConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createLinuxMintConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	c := mirror.LegacyHttpClient{}
-	head, err := c.ReadDir(linuxmintMirror)
+	head, err := c.ReadDir(ctx, linuxmintMirror)
 	if err != nil {
 		return nil, err
 	}
@@ -36,7 +37,7 @@ func createLinuxMintConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 
 	for _, releaseDir := range fiveMostRecent {
 		wg.Go(func() {
-			configs, err := getLinuxMintReleaseConfigs(releaseDir, isoRe, csErrs)
+			configs, err := getLinuxMintReleaseConfigs(ctx, releaseDir, isoRe, csErrs)
 			if err != nil {
 				errs <- Failure{Release: releaseDir.Name, Error: err}
 				return
@@ -49,9 +50,9 @@ func createLinuxMintConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 	return waitForConfigs(ch, wg), nil
 }
 
-func getLinuxMintReleaseConfigs(dir mirror.SubDirEntry, isoRe *regexp.Regexp, csErrs chan<- Failure) (iter.Seq[Config], error) {
+func getLinuxMintReleaseConfigs(ctx context.Context, dir mirror.SubDirEntry, isoRe *regexp.Regexp, csErrs chan<- Failure) (iter.Seq[Config], error) {
 	release := dir.Name
-	contents, err := dir.Fetch()
+	contents, err := dir.Fetch(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -60,7 +61,7 @@ func getLinuxMintReleaseConfigs(dir mirror.SubDirEntry, isoRe *regexp.Regexp, cs
 	for k, f := range contents.Files {
 		k = strings.ToLower(k)
 		if strings.HasSuffix(k, ".txt") && strings.Contains(k, "sum") {
-			checksums, err = cs.Build(cs.Whitespace, f)
+			checksums, err = cs.Build(ctx, cs.Whitespace, f)
 			if err != nil {
 				csErrs <- Failure{Release: release, Error: err}
 			} else {
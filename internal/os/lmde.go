@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"regexp"
 	"strings"
 
@@ -18,9 +19,9 @@ var LMDE = OS{
 	ConfigFunction: createLmdeConfigs,
 }
 
-func createLmdeConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createLmdeConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	c := mirror.LegacyHttpClient{}
-	head, err := c.ReadDir(lmdeMirror)
+	head, err := c.ReadDir(ctx, lmdeMirror)
 	if err != nil {
 		return nil, err
 	}
@@ -28,7 +29,7 @@ func createLmdeConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 	for k, f := range head.Files {
 		k = strings.ToLower(k)
 		if strings.HasSuffix(k, "txt") && strings.Contains(k, "sum") {
-			checksums, err = cs.Build(cs.Whitespace, f)
+			checksums, err = cs.Build(ctx, cs.Whitespace, f)
 			if err != nil {
 				csErrs <- Failure{Error: err}
 			} else {
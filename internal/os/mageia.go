@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 
@@ -20,8 +21,8 @@ var Mageia = OS{
 	ConfigFunction: createMageiaConfigs,
 }
 
-func createMageiaConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
-	releases, _, err := getBasicReleases(mageiaMirror, mageiaReleaseRe, -1)
+func createMageiaConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+	releases, _, err := getBasicReleases(ctx, mageiaMirror, mageiaReleaseRe, -1)
 	if err != nil {
 		return nil, err
 	}
@@ -31,7 +32,7 @@ func createMageiaConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 	for release := range releases {
 		mirror := mageiaMirror + release + "/"
 		wg.Go(func() {
-			editions, _, err := getBasicReleases(mirror, editionRe, -1)
+			editions, _, err := getBasicReleases(ctx, mirror, editionRe, -1)
 			if err != nil {
 				errs <- Failure{Release: release, Error: err}
 				return
@@ -40,7 +41,7 @@ func createMageiaConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 				isoText := fmt.Sprintf("Mageia-%s-Live-%s-x86_64", release, edition)
 				wg.Go(func() {
 					url := mirror + isoText + "/" + isoText + ".iso"
-					checksum, err := cs.SingleWhitespace(url + ".sha512")
+					checksum, err := cs.SingleWhitespace(ctx, url+".sha512")
 					if err != nil {
 						csErrs <- Failure{Release: release, Edition: edition, Error: err}
 					}
@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"strings"
 	"sync"
 
@@ -22,21 +23,21 @@ var Manjaro = OS{
 	ConfigFunction: createManjaroConfigs,
 }
 
-func createManjaroConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createManjaroConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	ch, wg := getChannels()
 	wg.Go(func() {
 		var data manjaroData
-		if err := web.CapturePageToJson(manjaroJsonUrl, &data); err != nil {
+		if err := web.CapturePageToJson(ctx, manjaroJsonUrl, &data); err != nil {
 			errs <- Failure{Error: err}
 			return
 		}
-		addManjaroConfigs(data.Official, x86_64, ch, wg, csErrs)
-		addManjaroConfigs(data.Community, x86_64, ch, wg, csErrs)
-		addManjaroConfigs(data.Arm.Generic, aarch64, ch, wg, csErrs)
+		addManjaroConfigs(ctx, data.Official, x86_64, ch, wg, csErrs)
+		addManjaroConfigs(ctx, data.Community, x86_64, ch, wg, csErrs)
+		addManjaroConfigs(ctx, data.Arm.Generic, aarch64, ch, wg, csErrs)
 	})
 
 	wg.Go(func() {
-		addManjaroSwayConfig(ch, errs, csErrs)
+		addManjaroSwayConfig(ctx, ch, errs, csErrs)
 	})
 	return waitForConfigs(ch, wg), nil
 }
@@ -45,11 +46,11 @@ type manjaroSwayData struct {
 	URL string `json:"url"`
 }
 
-func addManjaroSwayConfig(ch chan Config, errs, csErrs chan<- Failure) {
+func addManjaroSwayConfig(ctx context.Context, ch chan Config, errs, csErrs chan<- Failure) {
 	release := "standard"
 	edition := "sway"
 	var data []manjaroSwayData
-	if err := web.CapturePageToJson(manjaroSwayJsonUrl, &data); err != nil {
+	if err := web.CapturePageToJson(ctx, manjaroSwayJsonUrl, &data); err != nil {
 		errs <- Failure{Release: release, Edition: edition, Error: err}
 		return
 	}
@@ -60,7 +61,7 @@ func addManjaroSwayConfig(ch chan Config, errs, csErrs chan<- Failure) {
 			break
 		}
 	}
-	checksum, err := cs.SingleWhitespace(url + ".sha256")
+	checksum, err := cs.SingleWhitespace(ctx, url+".sha256")
 	if err != nil {
 		csErrs <- Failure{Release: release, Edition: edition, Error: err}
 	}
@@ -73,15 +74,15 @@ func addManjaroSwayConfig(ch chan Config, errs, csErrs chan<- Failure) {
 	}
 }
 
-func addManjaroConfigs(data map[string]manjaroEntry, arch Arch, ch chan Config, wg *sync.WaitGroup, csErrs chan<- Failure) {
+func addManjaroConfigs(ctx context.Context, data map[string]manjaroEntry, arch Arch, ch chan Config, wg *sync.WaitGroup, csErrs chan<- Failure) {
 	for edition, entry := range data {
-		addManjaroConfig(entry, edition, false, arch, ch, wg, csErrs)
+		addManjaroConfig(ctx, entry, edition, false, arch, ch, wg, csErrs)
 	}
 }
 
-func addManjaroConfig(entry manjaroEntry, edition string, minimal bool, arch Arch, ch chan Config, wg *sync.WaitGroup, csErrs chan<- Failure) {
+func addManjaroConfig(ctx context.Context, entry manjaroEntry, edition string, minimal bool, arch Arch, ch chan Config, wg *sync.WaitGroup, csErrs chan<- Failure) {
 	if entry.Minimal != nil {
-		addManjaroConfig(*entry.Minimal, edition, true, arch, ch, wg, csErrs)
+		addManjaroConfig(ctx, *entry.Minimal, edition, true, arch, ch, wg, csErrs)
 	}
 	if entry.Image == "" {
 		return
@@ -94,7 +95,7 @@ func addManjaroConfig(entry manjaroEntry, edition string, minimal bool, arch Arc
 		release = "standard"
 	}
 	wg.Go(func() {
-		checksum, err := cs.SingleWhitespace(entry.Checksum)
+		checksum, err := cs.SingleWhitespace(ctx, entry.Checksum)
 		if err != nil {
 			csErrs <- Failure{Release: release, Edition: edition, Arch: arch, Error: err}
 		}
@@ -1,10 +1,10 @@
 package os
 
 import (
+	"context"
 	"regexp"
 
 	"github.com/quickemu-project/quickget_configs/internal/cs"
-	"github.com/quickemu-project/quickget_configs/internal/mirror"
 )
 
 const (
@@ -19,9 +19,9 @@ var MXLinux = OS{
 	ConfigFunction: createMXLinuxConfigs,
 }
 
-func createMXLinuxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
-	c := mirror.SourceForgeClient{}
-	head, err := c.ReadDir(mxlinuxMirror)
+func createMXLinuxConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+	c := sourceforgeClient
+	head, err := c.ReadDir(ctx, mxlinuxMirror)
 	if err != nil {
 		return nil, err
 	}
@@ -30,7 +30,7 @@ func createMXLinuxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 
 	for edition, d := range head.SubDirs {
 		wg.Go(func() {
-			contents, err := d.Fetch()
+			contents, err := d.Fetch(ctx)
 			if err != nil {
 				errs <- Failure{Edition: edition, Error: err}
 				return
@@ -38,11 +38,12 @@ func createMXLinuxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 			for f, match := range contents.FileMatches(isoRe) {
 				release := match[1]
 
-				var checksum string
+				checksum := f.Checksum()
 				if cf, ok := contents.Files[f.Name+".sha256"]; ok {
-					checksum, err = cs.SingleWhitespace(cf)
-					if err != nil {
+					if sum, err := cs.SingleWhitespace(ctx, cf); err != nil {
 						csErrs <- Failure{Release: release, Edition: edition, Error: err}
+					} else {
+						checksum = sum
 					}
 				}
 
@@ -54,7 +55,7 @@ func createMXLinuxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 					Release: release,
 					Edition: edition,
 					ISO: []Source{
-						webSource(f.URL.String(), checksum, "", f.Name),
+						mirrorFileSource(f, checksum),
 					},
 				}
 			}
@@ -1,6 +1,10 @@
 package os
 
-import "github.com/quickemu-project/quickget_configs/internal/cs"
+import (
+	"context"
+
+	"github.com/quickemu-project/quickget_configs/internal/cs"
+)
 
 const netbootMirror = "https://boot.netboot.xyz/ipxe/"
 
@@ -12,9 +16,9 @@ var Netboot = OS{
 	ConfigFunction: createNetbootConfigs,
 }
 
-func createNetbootConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createNetbootConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	checksumUrl := netbootMirror + "netboot.xyz-sha256-checksums.txt"
-	checksums, err := cs.Build(cs.Whitespace, checksumUrl)
+	checksums, err := cs.Build(ctx, cs.Whitespace, checksumUrl)
 	if err != nil {
 		csErrs <- Failure{Error: err}
 	}
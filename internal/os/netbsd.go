@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/quickemu-project/quickget_configs/internal/cs"
@@ -22,8 +23,8 @@ var NetBSD = OS{
 	ConfigFunction: createNetBSDConfigs,
 }
 
-func createNetBSDConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
-	releases, err := getSortedReleasesFunc(netbsdMirror, netbsdReleaseRe, 4, semverCompare)
+func createNetBSDConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+	releases, err := getSortedReleasesFunc(ctx, netbsdMirror, netbsdReleaseRe, 4, semverCompare)
 	if err != nil {
 		return nil, err
 	}
@@ -32,7 +33,7 @@ func createNetBSDConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 		mirror := netbsdMirror + release + "/"
 		go func() {
 			defer wg.Done()
-			checksums, err := cs.Build(cs.Sha512Regex, mirror+"SHA512")
+			checksums, err := cs.Build(ctx, cs.Sha512Regex, mirror+"SHA512")
 			if err != nil {
 				csErrs <- Failure{Release: release, Error: err}
 			}
@@ -1,7 +1,7 @@
 package os
 
 import (
-	"errors"
+	"context"
 	"regexp"
 	"strings"
 
@@ -19,9 +19,9 @@ var Nitrux = OS{
 	ConfigFunction: createNitruxConfigs,
 }
 
-func createNitruxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
-	c := mirror.SourceForgeClient{}
-	head, err := c.ReadDir(nitruxMirror)
+func createNitruxConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+	c := sourceforgeClient
+	head, err := c.ReadDir(ctx, nitruxMirror)
 	if err != nil {
 		return nil, err
 	}
@@ -30,9 +30,9 @@ func createNitruxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 
 	isoSubDir, ok := head.SubDirs["ISO"]
 	if !ok {
-		return nil, errors.New("iso directory doesn't exist")
+		return nil, noMatch("iso directory doesn't exist")
 	}
-	isoDir, err := isoSubDir.Fetch()
+	isoDir, err := isoSubDir.Fetch(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -40,7 +40,7 @@ func createNitruxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 
 	var checksumDir *mirror.Directory
 	if d, ok := head.SubDirs["SHA512"]; ok {
-		checksumDir, err = d.Fetch()
+		checksumDir, err = d.Fetch(ctx)
 		if err != nil {
 			csErrs <- Failure{Release: release, Error: err}
 		}
@@ -54,13 +54,14 @@ func createNitruxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 			continue
 		}
 
-		var checksum string
+		checksum := f.Checksum()
 		checksumName := strings.TrimSuffix(f.Name, ".iso") + ".sha512"
 		if checksumDir != nil {
 			if cf, ok := checksumDir.Files[checksumName]; ok {
-				checksum, err = cs.SingleWhitespace(cf)
-				if err != nil {
+				if sum, err := cs.SingleWhitespace(ctx, cf); err != nil {
 					csErrs <- Failure{Release: release, Edition: edition, Arch: arch, Error: err}
+				} else {
+					checksum = sum
 				}
 			}
 		}
@@ -70,7 +71,7 @@ func createNitruxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 			Edition: edition,
 			Arch:    arch,
 			ISO: []Source{
-				webSource(f.URL.String(), checksum, "", f.Name),
+				mirrorFileSource(f, checksum),
 			},
 		})
 	}
@@ -1,20 +1,24 @@
 package os
 
 import (
+	"context"
 	"fmt"
 	"iter"
 	"regexp"
 	"strings"
 
 	"github.com/quickemu-project/quickget_configs/internal/cs"
+	"github.com/quickemu-project/quickget_configs/internal/mirror"
 	"github.com/quickemu-project/quickget_configs/internal/web"
 )
 
 const (
-	nixDataUrl     = "https://nix-channels.s3.amazonaws.com/?delimiter=/"
+	nixBucket      = "https://nix-channels.s3.amazonaws.com/"
 	nixDownloadUrl = "https://channels.nixos.org"
 )
 
+var nixClient = mirror.S3Client{}
+
 var NixOS = OS{
 	Name:           "nixos",
 	PrettyName:     "NixOS",
@@ -23,8 +27,8 @@ var NixOS = OS{
 	ConfigFunction: createNixOSConfigs,
 }
 
-func createNixOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
-	releases, err := getNixReleases(6)
+func createNixOSConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+	releases, err := getNixReleases(ctx, 6)
 	if err != nil {
 		return nil, err
 	}
@@ -32,37 +36,31 @@ func createNixOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 	ch, wg := getChannels()
 
 	for release := range releases {
-		mirror := fmt.Sprintf("%s&prefix=nixos-%s/", nixDataUrl, release)
 		wg.Go(func() {
-			data, err := getNixXML(mirror)
+			contents, err := nixClient.ReadDir(ctx, nixBucket+"nixos-"+release+"/")
 			if err != nil {
 				errs <- Failure{Release: release, Error: err}
 				return
 			}
-			for _, entry := range data.Contents {
-				if !strings.HasSuffix(entry.Key, ".iso") {
-					continue
-				}
-				match := isoRe.FindStringSubmatch(entry.Key)
-				if match == nil {
+			for f, match := range contents.FileMatches(isoRe) {
+				if !strings.HasSuffix(f.Name, ".iso") {
 					continue
 				}
-
-				name, edition := match[0], match[1]
+				name, edition := f.Name, match[1]
 				arch, v := NewArch(match[2])
 				if !v {
 					continue
 				}
 
 				url := fmt.Sprintf("%s/nixos-%s/%s", nixDownloadUrl, release, name)
-				url, err = web.FinalRedirectUrl(url)
+				url, err = web.FinalRedirectUrl(ctx, url)
 				if err != nil {
 					errs <- Failure{Release: release, Edition: edition, Arch: arch, Error: err}
 					continue
 				}
 
 				wg.Go(func() {
-					checksum, err := cs.SingleWhitespace(url + ".sha256")
+					checksum, err := cs.SingleWhitespace(ctx, url+".sha256")
 					if err != nil {
 						csErrs <- Failure{Release: release, Edition: edition, Arch: arch, Error: err}
 					}
@@ -81,23 +79,17 @@ func createNixOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 	return waitForConfigs(ch, wg), nil
 }
 
-func getNixXML(url string) (*nixReleases, error) {
-	var releaseData nixReleases
-	if err := web.CapturePageToXml(url, &releaseData); err != nil {
-		return nil, err
-	}
-	return &releaseData, nil
-}
-
-func getNixReleases(count int) (iter.Seq[string], error) {
-	releaseData, err := getNixXML(nixDataUrl)
+func getNixReleases(ctx context.Context, count int) (iter.Seq[string], error) {
+	head, err := nixClient.ReadDir(ctx, nixBucket)
 	if err != nil {
 		return nil, err
 	}
+	// Each channel is an object redirecting to its latest build
+	channels := head.NameSortedFiles(strings.Compare)
 	return func(yield func(string) bool) {
 		releaseRe := regexp.MustCompile(`nixos-(([0-9]+.[0-9]+|(unstable))(?:-small)?)`)
-		for i := len(releaseData.Contents) - 1; i >= 0 && count > 0; i-- {
-			if match := releaseRe.FindStringSubmatch(releaseData.Contents[i].Key); match != nil {
+		for i := len(channels) - 1; i >= 0 && count > 0; i-- {
+			if match := releaseRe.FindStringSubmatch(channels[i].Name); match != nil {
 				if !yield(match[1]) {
 					return
 				}
@@ -106,9 +98,3 @@ func getNixReleases(count int) (iter.Seq[string], error) {
 		}
 	}, nil
 }
-
-type nixReleases struct {
-	Contents []struct {
-		Key string
-	}
-}
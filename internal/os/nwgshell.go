@@ -1,10 +1,10 @@
 package os
 
 import (
+	"context"
 	"regexp"
 
 	"github.com/quickemu-project/quickget_configs/internal/cs"
-	"github.com/quickemu-project/quickget_configs/internal/mirror"
 )
 
 const nwgshellMirror = "https://sourceforge.net/projects/nwg-iso/files/"
@@ -17,16 +17,16 @@ var NWGShell = OS{
 	ConfigFunction: createNwgShellConfigs,
 }
 
-func createNwgShellConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
-	c := mirror.SourceForgeClient{}
-	head, err := c.ReadDir(nwgshellMirror)
+func createNwgShellConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+	c := sourceforgeClient
+	head, err := c.ReadDir(ctx, nwgshellMirror)
 	if err != nil {
 		return nil, err
 	}
 
 	checksums := make(map[string]string)
 	if f, ok := head.Files["sha256sums.txt"]; ok {
-		checksums, err = cs.Build(cs.Whitespace, f)
+		checksums, err = cs.Build(ctx, cs.Whitespace, f)
 		if err != nil {
 			csErrs <- Failure{Error: err}
 		}
@@ -36,12 +36,15 @@ func createNwgShellConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 
 	var configs []Config
 	for f, match := range head.FileMatches(isoRe) {
-		checksum := checksums[f.Name]
+		checksum, ok := checksums[f.Name]
+		if !ok {
+			checksum = f.Checksum()
+		}
 
 		configs = append(configs, Config{
 			Release: match[1],
 			ISO: []Source{
-				webSource(f.URL.String(), checksum, "", f.Name),
+				mirrorFileSource(f, checksum),
 			},
 		})
 	}
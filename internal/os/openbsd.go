@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"strings"
 	"sync"
 
@@ -21,8 +22,8 @@ var OpenBSD = OS{
 	ConfigFunction: createOpenBSDConfigs,
 }
 
-func createOpenBSDConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
-	releases, numReleases, err := getBasicReleases(openbsdMirror, openbsdReleaseRe, 4)
+func createOpenBSDConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+	releases, numReleases, err := getBasicReleases(ctx, openbsdMirror, openbsdReleaseRe, 4)
 	if err != nil {
 		return nil, err
 	}
@@ -30,20 +31,20 @@ func createOpenBSDConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 
 	for release := range releases {
 		amd64Mirror := openbsdMirror + release + "/amd64/"
-		go addOpenBSDConfig(amd64Mirror, release, x86_64, ch, wg, csErrs)
+		go addOpenBSDConfig(ctx, amd64Mirror, release, x86_64, ch, wg, csErrs)
 		arm64Mirror := openbsdMirror + release + "/arm64/"
-		go addOpenBSDConfig(arm64Mirror, release, aarch64, ch, wg, csErrs)
+		go addOpenBSDConfig(ctx, arm64Mirror, release, aarch64, ch, wg, csErrs)
 		riscv64Mirror := openbsdMirror + release + "/riscv64/"
-		go addOpenBSDConfig(riscv64Mirror, release, riscv64, ch, wg, csErrs)
+		go addOpenBSDConfig(ctx, riscv64Mirror, release, riscv64, ch, wg, csErrs)
 	}
 
 	return waitForConfigs(ch, wg), nil
 }
 
-func addOpenBSDConfig(mirror, release string, arch Arch, ch chan Config, wg *sync.WaitGroup, csErrs chan<- Failure) {
+func addOpenBSDConfig(ctx context.Context, mirror, release string, arch Arch, ch chan Config, wg *sync.WaitGroup, csErrs chan<- Failure) {
 	defer wg.Done()
 
-	checksums, err := cs.Build(cs.Sha256Regex, mirror+"SHA256")
+	checksums, err := cs.Build(ctx, cs.Sha256Regex, mirror+"SHA256")
 	if err != nil {
 		csErrs <- Failure{Release: release, Arch: arch, Error: err}
 	}
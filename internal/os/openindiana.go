@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"regexp"
 
 	"github.com/quickemu-project/quickget_configs/internal/cs"
@@ -22,8 +23,8 @@ var OpenIndiana = OS{
 	ConfigFunction: createOpenIndianaConfigs,
 }
 
-func createOpenIndianaConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
-	releases, _, err := getReverseReleases(openIndianaMirror, openIndianaReleaseRe, 5)
+func createOpenIndianaConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+	releases, _, err := getReverseReleases(ctx, openIndianaMirror, openIndianaReleaseRe, 5)
 	if err != nil {
 		return nil, err
 	}
@@ -33,7 +34,7 @@ func createOpenIndianaConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 	for release := range releases {
 		mirror := openIndianaMirror + release + "/"
 		wg.Go(func() {
-			page, err := web.CapturePage(mirror)
+			page, err := web.CapturePage(ctx, mirror)
 			if err != nil {
 				errs <- Failure{Release: release, Error: err}
 				return
@@ -44,7 +45,7 @@ func createOpenIndianaConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 				url := mirror + iso
 				checksumUrl := url + ".sha256sum"
 				wg.Go(func() {
-					checksum, err := cs.SingleWhitespace(checksumUrl)
+					checksum, err := cs.SingleWhitespace(ctx, checksumUrl)
 					if err != nil {
 						csErrs <- Failure{Release: release, Edition: edition, Error: err}
 					}
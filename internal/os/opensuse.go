@@ -1,9 +1,11 @@
 package os
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/quickemu-project/quickget_configs/internal/cs"
+	"github.com/quickemu-project/quickget_configs/pkg/quickgetdata"
 )
 
 const (
@@ -19,12 +21,12 @@ var OpenSUSE = OS{
 	ConfigFunction: createOpenSUSEConfigs,
 }
 
-func createOpenSUSEConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
-	releases, _, err := getReverseReleases(opensuseLeapMirror, opensuseReleaseRe, 5)
+func createOpenSUSEConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+	releases, _, err := getReverseReleases(ctx, opensuseLeapMirror, opensuseReleaseRe, 5)
 	if err != nil {
 		return nil, err
 	}
-	architectures := []Arch{x86_64, aarch64}
+	architectures := []Arch{x86_64, aarch64, ppc64le, s390x}
 	ch, wg := getChannels()
 	for release := range releases {
 		if release == "42.3" {
@@ -32,9 +34,9 @@ func createOpenSUSEConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 		}
 		for _, arch := range architectures {
 			wg.Go(func() {
-				iso := fmt.Sprintf("openSUSE-Leap-%s-DVD-x86_64-Current.iso", release)
+				iso := fmt.Sprintf("openSUSE-Leap-%s-DVD-%s-Current.iso", release, arch)
 				url := fmt.Sprintf("%s%s/iso/%s", opensuseLeapMirror, release, iso)
-				checksum, err := cs.SingleWhitespace(url + ".sha256")
+				checksum, err := cs.SingleWhitespace(ctx, url+".sha256")
 				if err != nil {
 					csErrs <- Failure{Release: release, Arch: arch, Error: err}
 				}
@@ -46,12 +48,32 @@ func createOpenSUSEConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 					},
 				}
 			})
+			if arch == x86_64 || arch == aarch64 {
+				wg.Go(func() {
+					img := fmt.Sprintf("openSUSE-Leap-%s-Minimal-VM.%s-Cloud.qcow2", release, arch)
+					url := fmt.Sprintf("%s%s/appliances/%s", opensuseLeapMirror, release, img)
+					config, err := getOpenSUSECloudConfig(ctx, release, arch, url)
+					if err != nil {
+						csErrs <- Failure{Release: release, Edition: "cloud", Arch: arch, Error: err}
+					}
+					ch <- config
+				})
+			}
 		}
 	}
 
+	wg.Go(func() {
+		url := "https://download.opensuse.org/tumbleweed/appliances/openSUSE-Tumbleweed-Minimal-VM.x86_64-Cloud.qcow2"
+		config, err := getOpenSUSECloudConfig(ctx, "tumbleweed", x86_64, url)
+		if err != nil {
+			csErrs <- Failure{Release: "tumbleweed", Edition: "cloud", Arch: x86_64, Error: err}
+		}
+		ch <- config
+	})
+
 	wg.Go(func() {
 		tumbleweedUrl := "https://download.opensuse.org/tumbleweed/iso/openSUSE-Tumbleweed-DVD-x86_64-Current.iso"
-		checksum, err := cs.SingleWhitespace(tumbleweedUrl + ".sha256")
+		checksum, err := cs.SingleWhitespace(ctx, tumbleweedUrl+".sha256")
 		if err != nil {
 			csErrs <- Failure{Release: "tumbleweed", Arch: x86_64, Error: err}
 		}
@@ -66,7 +88,7 @@ func createOpenSUSEConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 
 	wg.Go(func() {
 		microOSUrl := "https://download.opensuse.org/tumbleweed/iso/openSUSE-MicroOS-DVD-x86_64-Current.iso"
-		checksum, err := cs.SingleWhitespace(microOSUrl + ".sha256")
+		checksum, err := cs.SingleWhitespace(ctx, microOSUrl+".sha256")
 		if err != nil {
 			csErrs <- Failure{Release: "microos", Arch: x86_64, Error: err}
 		}
@@ -81,7 +103,7 @@ func createOpenSUSEConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 
 	wg.Go(func() {
 		aeonUrl := "https://mirrorcache.opensuse.org/tumbleweed/appliances/iso/opensuse-aeon.x86_64.iso"
-		checksum, err := cs.SingleWhitespace(aeonUrl + ".sha256")
+		checksum, err := cs.SingleWhitespace(ctx, aeonUrl+".sha256")
 		if err != nil {
 			csErrs <- Failure{Release: "aeon", Arch: x86_64, Error: err}
 		}
@@ -96,3 +118,20 @@ func createOpenSUSEConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 
 	return waitForConfigs(ch, wg), nil
 }
+
+// The Minimal-VM appliance's Cloud flavour is configured through cloud-init, unlike the kvm-and-xen flavour which uses Combustion
+func getOpenSUSECloudConfig(ctx context.Context, release string, arch Arch, url string) (Config, error) {
+	checksum, err := cs.SingleWhitespace(ctx, url+".sha256")
+	return Config{
+		Release: release,
+		Edition: "cloud",
+		Arch:    arch,
+		DiskImages: []Disk{
+			{
+				Source: urlChecksumSource(url, checksum),
+				Format: quickgetdata.Qcow2,
+			},
+		},
+		CloudInit: true,
+	}, err
+}
@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"fmt"
 	"iter"
 	"regexp"
@@ -23,8 +24,8 @@ var OracleLinux = OS{
 	ConfigFunction: createOracleLinuxConfigs,
 }
 
-func createOracleLinuxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
-	page, err := web.CapturePage(oracleLinuxChecksumMirror)
+func createOracleLinuxConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+	page, err := web.CapturePage(ctx, oracleLinuxChecksumMirror)
 	if err != nil {
 		return nil, err
 	}
@@ -57,7 +58,7 @@ func createOracleLinuxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 			}
 
 			release := major + "." + minor
-			checksumData, err := web.CapturePage(oracleLinuxChecksumMirror + match[1])
+			checksumData, err := web.CapturePage(ctx, oracleLinuxChecksumMirror+match[1])
 			if err != nil {
 				errs <- Failure{Release: release, Error: err}
 				return
@@ -71,11 +72,11 @@ func createOracleLinuxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 				defer stop()
 				checksum, hasChecksum := nextSplit()
 				if !hasChecksum {
-					errs <- Failure{Release: release, Error: fmt.Errorf("Line %s does not contain the required fields", line)}
+					errs <- Failure{Release: release, Error: noMatch("Line %s does not contain the required fields", line)}
 				}
 				iso, hasIso := nextSplit()
 				if !hasIso {
-					errs <- Failure{Release: release, Error: fmt.Errorf("Line %s does not contain the required fields", line)}
+					errs <- Failure{Release: release, Error: noMatch("Line %s does not contain the required fields", line)}
 				}
 				url := fmt.Sprintf("https://yum.oracle.com/ISOS/OracleLinux/OL%s/u%s/%s/%s", major, minor, arch, iso)
 				ch <- Config{
@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"regexp"
 	"slices"
 	"strings"
@@ -25,9 +26,9 @@ var ParrotSec = OS{
 	ConfigFunction: createParrotSecConfigs,
 }
 
-func createParrotSecConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createParrotSecConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	c := mirror.LegacyHttpClient{}
-	head, err := c.ReadDir(parrotSecMirror)
+	head, err := c.ReadDir(ctx, parrotSecMirror)
 	if err != nil {
 		return nil, err
 	}
@@ -41,7 +42,7 @@ func createParrotSecConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 	for _, releaseDir := range threeMostRecent {
 		wg.Go(func() {
 			release := releaseDir.Name
-			contents, err := releaseDir.Fetch()
+			contents, err := releaseDir.Fetch(ctx)
 			if err != nil {
 				errs <- Failure{Release: release, Error: err}
 				return
@@ -53,7 +54,7 @@ func createParrotSecConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 				return strings.HasSuffix(k, ".txt") && strings.Contains(k, "hash")
 			})
 			if ok {
-				page, err := web.CapturePage(cf.URL)
+				page, err := web.CapturePage(ctx, cf.URL)
 				if err != nil {
 					csErrs <- Failure{Release: release, Error: err}
 				} else {
@@ -1,6 +1,10 @@
 package os
 
-import "github.com/quickemu-project/quickget_configs/internal/cs"
+import (
+	"context"
+
+	"github.com/quickemu-project/quickget_configs/internal/cs"
+)
 
 var Peppermint = OS{
 	Name:           "peppermint",
@@ -14,7 +18,7 @@ var Peppermint = OS{
 // Non-matching checksum & iso naming, etc
 // Therefore, we'll just hardcode values (yes, this was done manually)
 
-func createPeppermintConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createPeppermintConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	releases := []peppermintRelease{
 		{
 			release:     "debian",
@@ -92,7 +96,7 @@ func createPeppermintConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 	for _, release := range releases {
 		go func() {
 			defer wg.Done()
-			cs, err := cs.SingleWhitespace(release.checksumUrl)
+			cs, err := cs.SingleWhitespace(ctx, sourceforgeDirect(release.checksumUrl))
 			if err != nil {
 				csErrs <- Failure{Release: release.release, Edition: release.edition, Error: err}
 			}
@@ -101,7 +105,7 @@ func createPeppermintConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 				Edition: release.edition,
 				Arch:    release.arch,
 				ISO: []Source{
-					urlChecksumSource(release.url, cs),
+					urlChecksumSource(sourceforgeDirect(release.url), cs),
 				},
 			}
 		}()
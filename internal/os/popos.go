@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"net/url"
 
 	"github.com/quickemu-project/quickget_configs/internal/web"
@@ -16,9 +17,9 @@ var PopOS = OS{
 	ConfigFunction: createPopOSConfigs,
 }
 
-func createPopOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createPopOSConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	// Pop!_OS does not have an API that can be used to get a list of releases, so we'll just try Ubuntu's
-	ubuntuReleases, err := getUbuntuReleases()
+	ubuntuReleases, err := getUbuntuReleases(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -38,7 +39,7 @@ func createPopOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 				url.RawQuery = rawQuery
 				var data popApi
 				// We'll ignore all errors
-				if err := web.CapturePageToJson(url, &data); err != nil {
+				if err := web.CapturePageToJson(ctx, url, &data); err != nil {
 					continue
 				}
 				if data.URL == "" {
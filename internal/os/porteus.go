@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"regexp"
 	"strings"
 
@@ -21,9 +22,9 @@ var Porteus = OS{
 	ConfigFunction: createPorteusConfigs,
 }
 
-func createPorteusConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createPorteusConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	c := mirror.HttpClient{}
-	head, err := c.ReadDir(porteusMirror)
+	head, err := c.ReadDir(ctx, porteusMirror)
 	if err != nil {
 		return nil, err
 	}
@@ -41,7 +42,7 @@ func createPorteusConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 	ch, wg := getChannels()
 	for release, d := range releases {
 		wg.Go(func() {
-			contents, err := d.Fetch()
+			contents, err := d.Fetch(ctx)
 			if err != nil {
 				errs <- Failure{Release: release, Error: err}
 				return
@@ -49,7 +50,7 @@ func createPorteusConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 
 			checksums := make(map[string]string)
 			if cf, ok := contents.Files["sha256sums.txt"]; ok {
-				checksums, err = cs.Build(cs.Whitespace, cf)
+				checksums, err = cs.Build(ctx, cs.Whitespace, cf)
 				if err != nil {
 					csErrs <- Failure{Release: release, Error: err}
 				}
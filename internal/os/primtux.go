@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"regexp"
 	"strings"
 
@@ -21,8 +22,8 @@ var Primtux = OS{
 	ConfigFunction: createPrimtuxConfigs,
 }
 
-func createPrimtuxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
-	page, err := web.CapturePage(primtuxMirror)
+func createPrimtuxConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+	page, err := web.CapturePage(ctx, primtuxMirror)
 	if err != nil {
 		return nil, err
 	}
@@ -37,7 +38,7 @@ func createPrimtuxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 			release := match[1]
 			url := match[2]
 			checksumUrl := strings.Replace(url, "iso", "md5", 1)
-			checksums, err := cs.SingleWhitespace(checksumUrl)
+			checksums, err := cs.SingleWhitespace(ctx, checksumUrl)
 			if err != nil {
 				csErrs <- Failure{Release: release, Error: err}
 			}
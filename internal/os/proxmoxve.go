@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"regexp"
 	"slices"
 
@@ -21,16 +22,16 @@ var ProxmoxVE = OS{
 	ConfigFunction: createProxmoxVEConfigs,
 }
 
-func createProxmoxVEConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createProxmoxVEConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	c := mirror.LegacyHttpClient{}
-	head, err := c.ReadDir(proxmoxVeMirror)
+	head, err := c.ReadDir(ctx, proxmoxVeMirror)
 	if err != nil {
 		return nil, err
 	}
 
 	checksums := make(map[string]string)
 	if f, ok := head.Files["SHA256SUMS"]; ok {
-		checksums, err = cs.Build(cs.Whitespace, f)
+		checksums, err = cs.Build(ctx, cs.Whitespace, f)
 		if err != nil {
 			csErrs <- Failure{Error: err}
 		}
@@ -1,7 +1,7 @@
 package os
 
 import (
-	"errors"
+	"context"
 	"strconv"
 	"strings"
 
@@ -25,9 +25,9 @@ var PureOS = OS{
 	ConfigFunction: createPureOSConfigs,
 }
 
-func createPureOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createPureOSConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	c := mirror.LegacyHttpClient{}
-	head, err := c.ReadDir(pureOsMirror)
+	head, err := c.ReadDir(ctx, pureOsMirror)
 	if err != nil {
 		return nil, err
 	}
@@ -43,14 +43,14 @@ func createPureOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 
 	for release, d := range head.SubDirs {
 		wg.Go(func() {
-			contents, err := d.Fetch()
+			contents, err := d.Fetch(ctx)
 			if err != nil {
 				errs <- Failure{Release: release, Error: err}
 				return
 			}
 
 			for edition, d := range contents.SubDirs {
-				contents, err := d.Fetch()
+				contents, err := d.Fetch(ctx)
 				if err != nil {
 					errs <- Failure{Release: release, Edition: edition, Error: err}
 					return
@@ -58,7 +58,7 @@ func createPureOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 
 				dates := contents.ModifiedTimeSortedSubdirs()
 				if len(dates) > 0 {
-					contents, err = dates[len(dates)-1].Fetch()
+					contents, err = dates[len(dates)-1].Fetch(ctx)
 					if err != nil {
 						errs <- Failure{Release: release, Edition: edition, Error: err}
 						return
@@ -69,7 +69,7 @@ func createPureOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 					return strings.HasSuffix(f.Name, ".iso")
 				})
 				if !ok {
-					errs <- Failure{Release: release, Edition: edition, Error: errors.New("could not find ISO in mirror")}
+					errs <- Failure{Release: release, Edition: edition, Error: noMatch("could not find ISO in mirror")}
 					return
 				}
 
@@ -79,7 +79,7 @@ func createPureOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 					return strings.Contains(f2.Name, isoName) && strings.Contains(f2.Name, "sha256")
 				})
 				if ok {
-					checksums, err = cs.Build(cs.Whitespace, cf)
+					checksums, err = cs.Build(ctx, cs.Whitespace, cf)
 					if err != nil {
 						csErrs <- Failure{Release: release, Edition: edition, Error: err}
 					}
@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"strings"
 
 	"github.com/quickemu-project/quickget_configs/internal/web"
@@ -19,11 +20,12 @@ var ReactOS = OS{
 	ConfigFunction: createReactOSConfigs,
 }
 
-func createReactOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
-	url, err := web.FinalRedirectUrl(reactOsLatestRel)
+func createReactOSConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+	url, err := web.FinalRedirectUrl(ctx, reactOsLatestRel)
 	if err != nil {
 		return nil, err
 	}
+	drivers := virtioWinDrivers(ctx, errs, csErrs)
 
 	return []Config{
 		{
@@ -33,6 +35,7 @@ func createReactOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 			ISO: []Source{
 				webSource(url, "", quickgetdata.Zip, ""),
 			},
+			FixedISO: drivers(),
 		},
 		{
 			Release: "latest",
@@ -41,6 +44,7 @@ func createReactOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 			ISO: []Source{
 				webSource(strings.Replace(url, "iso", "live", 1), "", quickgetdata.Zip, ""),
 			},
+			FixedISO: drivers(),
 		},
 	}, nil
 }
@@ -1,7 +1,7 @@
 package os
 
 import (
-	"errors"
+	"context"
 	"regexp"
 
 	"github.com/quickemu-project/quickget_configs/internal/web"
@@ -21,8 +21,8 @@ var RebornOS = OS{
 	ConfigFunction: createRebornOSConfigs,
 }
 
-func createRebornOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
-	page, err := web.CapturePage(rebornOsDlPage)
+func createRebornOSConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+	page, err := web.CapturePage(ctx, rebornOsDlPage)
 	if err != nil {
 		return nil, err
 	}
@@ -32,14 +32,14 @@ func createRebornOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 	release := "latest"
 	urlResult := urlRe.FindStringSubmatch(page)
 	if urlResult == nil {
-		return nil, errors.New("Could not find download URL in HTML")
+		return nil, noMatch("Could not find download URL in HTML")
 	}
 	url := urlResult[1]
 
 	checksumResult := csRe.FindStringSubmatch(page)
 	var checksum string
 	if checksumResult == nil {
-		csErrs <- Failure{Release: release, Error: errors.New("Could not find checksum from HTML")}
+		csErrs <- Failure{Release: release, Error: missingChecksum("Could not find checksum from HTML")}
 	} else {
 		checksum = checksumResult[1]
 	}
@@ -1,14 +1,23 @@
 package os
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
 
 	"github.com/quickemu-project/quickget_configs/internal/cs"
+	"github.com/quickemu-project/quickget_configs/internal/web"
+	"github.com/quickemu-project/quickget_configs/pkg/quickgetdata"
 )
 
 const (
 	rockyMirror    = "https://dl.rockylinux.org/vault/rocky/"
 	rockyReleaseRe = `href="(\d+\.\d+)/"`
+	rockyCloudRe   = `href="(Rocky-\d+-GenericCloud-Base-[\d\.]+-[\d\.]+\.[^.]+\.qcow2)"`
 )
 
 var RockyLinux = OS{
@@ -19,13 +28,14 @@ var RockyLinux = OS{
 	ConfigFunction: createRockyLinuxConfigs,
 }
 
-func createRockyLinuxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
-	releases, numReleases, err := getReverseReleases(rockyMirror, rockyReleaseRe, 3)
+func createRockyLinuxConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+	releases, numReleases, err := getReverseReleases(ctx, rockyMirror, rockyReleaseRe, 3)
 	if err != nil {
 		return nil, err
 	}
 	ch, wg := getChannelsWith(numReleases * len(x86_64_aarch64))
 	editions := [...]string{"boot", "dvd", "minimal"}
+	cloudRe := regexp.MustCompile(rockyCloudRe)
 
 	for release := range releases {
 		for _, arch := range x86_64_aarch64 {
@@ -33,7 +43,7 @@ func createRockyLinuxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 				defer wg.Done()
 				url := rockyMirror + release + "/isos/" + string(arch) + "/"
 
-				checksums, err := cs.Build(cs.Sha256Regex, url+"CHECKSUM")
+				checksums, err := cs.Build(ctx, cs.Sha256Regex, url+"CHECKSUM")
 				if err != nil {
 					csErrs <- Failure{Release: release, Arch: arch, Error: err}
 				}
@@ -51,8 +61,53 @@ func createRockyLinuxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 						},
 					}
 				}
+
+				config, err, csErr := getRockyCloudConfig(ctx, release, arch, cloudRe)
+				if err != nil {
+					errs <- Failure{Release: release, Edition: "cloud", Arch: arch, Error: err}
+				}
+				if csErr != nil {
+					csErrs <- Failure{Release: release, Edition: "cloud", Arch: arch, Error: csErr}
+				}
+				if config != nil {
+					ch <- *config
+				}
 			}()
 		}
 	}
 	return waitForConfigs(ch, wg), nil
 }
+
+// Cloud images are rebuilt during a release's lifetime. The mirror lists them by build date, so the final match is the newest.
+// Many releases in the vault never had a GenericCloud image, so a missing directory or image returns no config and no error
+func getRockyCloudConfig(ctx context.Context, release string, arch Arch, cloudRe *regexp.Regexp) (config *Config, err error, csErr error) {
+	mirror := rockyMirror + release + "/images/" + string(arch) + "/"
+	page, err := web.CapturePage(ctx, mirror)
+	var statusErr *web.StatusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+		return nil, nil, nil
+	} else if err != nil {
+		return nil, err, nil
+	}
+	matches := cloudRe.FindAllStringSubmatch(page, -1)
+	if len(matches) == 0 {
+		return nil, nil, nil
+	}
+	img := slices.MaxFunc(matches, func(a, b []string) int {
+		return strings.Compare(a[1], b[1])
+	})[1]
+
+	checksums, csErr := cs.Build(ctx, cs.Sha256Regex, mirror+"CHECKSUM")
+	return &Config{
+		Release: release,
+		Edition: "cloud",
+		Arch:    arch,
+		DiskImages: []Disk{
+			{
+				Source: webSource(mirror+img, checksums[img], "", img),
+				Format: quickgetdata.Qcow2,
+			},
+		},
+		CloudInit: true,
+	}, nil, csErr
+}
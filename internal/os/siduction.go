@@ -1,7 +1,7 @@
 package os
 
 import (
-	"fmt"
+	"context"
 	"regexp"
 
 	"github.com/quickemu-project/quickget_configs/internal/cs"
@@ -22,11 +22,11 @@ var Siduction = OS{
 	ConfigFunction: createSiductionConfigs,
 }
 
-func createSiductionConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createSiductionConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	subdirRe := regexp.MustCompile(siductionSubdirRe)
 	release := "latest"
 
-	subdirs, _, err := getBasicReleases(siductionMirror, subdirRe, 1)
+	subdirs, _, err := getBasicReleases(ctx, siductionMirror, subdirRe, 1)
 	if err != nil {
 		return nil, err
 	}
@@ -36,27 +36,27 @@ func createSiductionConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 
 	for subdir := range subdirs {
 		url := siductionMirror + subdir + "/"
-		editions, _, err := getBasicReleases(url, subdirRe, -1)
+		editions, _, err := getBasicReleases(ctx, url, subdirRe, -1)
 		if err != nil {
 			return nil, err
 		}
 		for edition := range editions {
 			wg.Go(func() {
 				url := url + edition + "/"
-				page, err := web.CapturePage(url)
+				page, err := web.CapturePage(ctx, url)
 				if err != nil {
 					errs <- Failure{Release: release, Edition: edition, Error: err}
 					return
 				}
 				isoMatch := isoRe.FindStringSubmatch(page)
 				if len(isoMatch) != 2 {
-					errs <- Failure{Release: release, Edition: edition, Error: fmt.Errorf("No iso found for %s", edition)}
+					errs <- Failure{Release: release, Edition: edition, Error: noMatch("No iso found for %s", edition)}
 					return
 				}
 				iso := isoMatch[1]
 				url += iso
 
-				checksum, err := cs.SingleWhitespace(url + ".sha256")
+				checksum, err := cs.SingleWhitespace(ctx, url+".sha256")
 				if err != nil {
 					csErrs <- Failure{Release: release, Edition: edition, Error: err}
 				}
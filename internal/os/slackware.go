@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 
@@ -21,8 +22,8 @@ var Slackware = OS{
 	ConfigFunction: createSlackwareConfigs,
 }
 
-func createSlackwareConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
-	page, err := web.CapturePage(slackwareMirror)
+func createSlackwareConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+	page, err := web.CapturePage(ctx, slackwareMirror)
 	if err != nil {
 		return nil, err
 	}
@@ -38,7 +39,7 @@ func createSlackwareConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 			release := match[2]
 			iso := fmt.Sprintf("slackware64-%s-install-dvd.iso", release)
 			url += iso
-			checksum, err := cs.SingleWhitespace(url + ".md5")
+			checksum, err := cs.SingleWhitespace(ctx, url+".md5")
 			if err != nil {
 				csErrs <- Failure{Release: release, Error: err}
 			}
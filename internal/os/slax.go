@@ -1,7 +1,7 @@
 package os
 
 import (
-	"errors"
+	"context"
 	"strings"
 
 	"github.com/quickemu-project/quickget_configs/internal/cs"
@@ -20,9 +20,9 @@ var Slax = OS{
 	ConfigFunction: createSlaxConfigs,
 }
 
-func createSlaxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createSlaxConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	c := mirror.HttpClient{}
-	head, err := c.ReadDir(slaxMirror)
+	head, err := c.ReadDir(ctx, slaxMirror)
 	if err != nil {
 		return nil, err
 	}
@@ -35,7 +35,7 @@ func createSlaxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 	})
 	if ok {
 		edition := "debian"
-		debianConfig, err := getSlaxConfig(release, edition, debianRelease, csErrs)
+		debianConfig, err := getSlaxConfig(ctx, release, edition, debianRelease, csErrs)
 		if err != nil {
 			errs <- Failure{Release: release, Edition: edition, Error: err}
 		} else {
@@ -48,7 +48,7 @@ func createSlaxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 	})
 	if ok {
 		edition := "slackware"
-		slackwareConfig, err := getSlaxConfig(release, edition, slackwareRelease, csErrs)
+		slackwareConfig, err := getSlaxConfig(ctx, release, edition, slackwareRelease, csErrs)
 		if err != nil {
 			errs <- Failure{Release: release, Edition: edition, Error: err}
 		} else {
@@ -59,15 +59,15 @@ func createSlaxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 	return configs, nil
 }
 
-func getSlaxConfig(release, edition string, dir mirror.SubDirEntry, csErrs chan<- Failure) (*Config, error) {
-	contents, err := dir.Fetch()
+func getSlaxConfig(ctx context.Context, release, edition string, dir mirror.SubDirEntry, csErrs chan<- Failure) (*Config, error) {
+	contents, err := dir.Fetch(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	checksums := make(map[string]string)
 	if f, ok := contents.Files["md5.txt"]; ok {
-		checksums, err = cs.Build(cs.Whitespace, f)
+		checksums, err = cs.Build(ctx, cs.Whitespace, f)
 		if err != nil {
 			csErrs <- Failure{Release: release, Edition: edition, Error: err}
 		}
@@ -86,5 +86,5 @@ func getSlaxConfig(release, edition string, dir mirror.SubDirEntry, csErrs chan<
 			}, nil
 		}
 	}
-	return nil, errors.New("could not find a matching ISO")
+	return nil, noMatch("could not find a matching ISO")
 }
@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"strings"
 
 	"github.com/quickemu-project/quickget_configs/internal/cs"
@@ -19,9 +20,9 @@ var Slint = OS{
 	ConfigFunction: createSlintConfigs,
 }
 
-func createSlintConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createSlintConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	c := mirror.LegacyHttpClient{}
-	head, err := c.ReadDir(slintMirror)
+	head, err := c.ReadDir(ctx, slintMirror)
 	if err != nil {
 		return nil, err
 	}
@@ -30,13 +31,13 @@ func createSlintConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 
 	for release, d := range head.SubDirs {
 		wg.Go(func() {
-			contents, err := d.Fetch()
+			contents, err := d.Fetch(ctx)
 			if err != nil {
 				errs <- Failure{Release: release, Error: err}
 				return
 			}
 			if id, ok := contents.SubDirs["iso"]; ok {
-				contents, err = id.Fetch()
+				contents, err = id.Fetch(ctx)
 				if err != nil {
 					errs <- Failure{Release: release, Error: err}
 					return
@@ -47,7 +48,7 @@ func createSlintConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 				if strings.HasSuffix(k, ".iso") {
 					var checksum string
 					if cf, ok := contents.Files[f.Name+".sha256"]; ok {
-						checksum, err = cs.SingleWhitespace(cf)
+						checksum, err = cs.SingleWhitespace(ctx, cf)
 						if err != nil {
 							csErrs <- Failure{Release: release, Error: err}
 						}
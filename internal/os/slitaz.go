@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"regexp"
 
 	"github.com/quickemu-project/quickget_configs/internal/cs"
@@ -20,9 +21,9 @@ var Slitaz = OS{
 	ConfigFunction: createSlitazConfigs,
 }
 
-func createSlitazConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createSlitazConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	c := mirror.HttpClient{}
-	head, err := c.ReadDir(slitazMirror)
+	head, err := c.ReadDir(ctx, slitazMirror)
 	if err != nil {
 		return nil, err
 	}
@@ -36,7 +37,7 @@ func createSlitazConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 
 			var checksum string
 			if f, ok := head.Files[match[1]+".md5"]; ok {
-				checksum, err = cs.SingleWhitespace(f)
+				checksum, err = cs.SingleWhitespace(ctx, f)
 				if err != nil {
 					csErrs <- Failure{Release: release, Edition: edition, Error: err}
 				}
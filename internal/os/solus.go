@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"regexp"
 	"strings"
 
@@ -21,9 +22,9 @@ var Solus = OS{
 	ConfigFunction: createSolusConfigs,
 }
 
-func createSolusConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createSolusConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	var releases []SolusData
-	if err := web.CapturePageAcceptingJson(solusMirror, &releases); err != nil {
+	if err := web.CapturePageAcceptingJson(ctx, solusMirror, &releases); err != nil {
 		return nil, err
 	}
 
@@ -35,7 +36,7 @@ func createSolusConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 		go func() {
 			defer wg.Done()
 			var isoData []SolusData
-			if err := web.CapturePageAcceptingJson(url, &isoData); err != nil {
+			if err := web.CapturePageAcceptingJson(ctx, url, &isoData); err != nil {
 				errs <- Failure{Release: release, Error: err}
 				return
 			}
@@ -47,7 +48,7 @@ func createSolusConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 				}
 				url := url + iso.Name
 				edition := isoMatch[1]
-				checksum, err := cs.SingleWhitespace(url + ".sha256sum")
+				checksum, err := cs.SingleWhitespace(ctx, url+".sha256sum")
 				if err != nil {
 					csErrs <- Failure{Release: release, Edition: edition, Error: err}
 				}
@@ -0,0 +1,31 @@
+package os
+
+import (
+	"net/url"
+
+	"github.com/quickemu-project/quickget_configs/internal/mirror"
+)
+
+// Links files directly to SourceForge's mirrors, listing some alternatives in case the mirror picked for the user fails
+var sourceforgeClient = mirror.SourceForgeClient{AlternativeMirrors: mirror.SourceForgeMirrors}
+
+// Returns a source for a file read from a mirror, including any alternative locations the mirror offers
+func mirrorFileSource(f mirror.File, checksum string) Source {
+	source := webSource(f.URL.String(), checksum, "", f.Name)
+	for _, m := range f.Mirrors {
+		source.Web.Mirrors = append(source.Web.Mirrors, m.String())
+	}
+	return source
+}
+
+// Converts a SourceForge download page URL to a direct link, returning the URL unchanged if it can't be converted
+func sourceforgeDirect(input string) string {
+	u, err := url.Parse(input)
+	if err != nil {
+		return input
+	}
+	if direct, ok := mirror.SourceForgeDirectURL(u, ""); ok {
+		return direct.String()
+	}
+	return input
+}
@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"regexp"
 
 	"github.com/quickemu-project/quickget_configs/internal/web"
@@ -22,8 +23,8 @@ var SparkyLinux = OS{
 	ConfigFunction: createSparkyLinuxConfigs,
 }
 
-func createSparkyLinuxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
-	dirs, numDirs, err := getBasicReleases(sparkyLinuxMirror, sparkyLinuxEditionRe, -1)
+func createSparkyLinuxConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+	dirs, numDirs, err := getBasicReleases(ctx, sparkyLinuxMirror, sparkyLinuxEditionRe, -1)
 	if err != nil {
 		return nil, err
 	}
@@ -37,7 +38,7 @@ func createSparkyLinuxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 		go func() {
 			defer wg.Done()
 			mirror := sparkyLinuxMirror + dir + "/"
-			page, err := web.CapturePage(mirror)
+			page, err := web.CapturePage(ctx, mirror)
 			if err != nil {
 				errs <- Failure{Edition: dir, Error: err}
 				return
@@ -49,7 +50,7 @@ func createSparkyLinuxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 					release := match[2]
 					edition := match[3]
 					url := mirror + match[1]
-					checksum, err := getSparkyLinuxChecksum(url+".allsums.txt/download", checksumRe)
+					checksum, err := getSparkyLinuxChecksum(ctx, url+".allsums.txt/download", checksumRe)
 					url += "/download"
 					if err != nil {
 						csErrs <- Failure{Release: release, Edition: edition, Error: err}
@@ -69,7 +70,7 @@ func createSparkyLinuxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 					release := match[1]
 					edition := match[2]
 					url := mirror + match[0]
-					checksum, err := getSparkyLinuxChecksum(url+".allsums.txt/download", checksumRe)
+					checksum, err := getSparkyLinuxChecksum(ctx, url+".allsums.txt/download", checksumRe)
 					url += "/download"
 					if err != nil {
 						csErrs <- Failure{Release: release, Edition: edition, Error: err}
@@ -89,8 +90,8 @@ func createSparkyLinuxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 	return waitForConfigs(ch, wg), nil
 }
 
-func getSparkyLinuxChecksum(url string, checksumRe *regexp.Regexp) (string, error) {
-	page, err := web.CapturePage(url)
+func getSparkyLinuxChecksum(ctx context.Context, url string, checksumRe *regexp.Regexp) (string, error) {
+	page, err := web.CapturePage(ctx, url)
 	if err != nil {
 		return "", err
 	}
@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"iter"
 	"regexp"
 
@@ -21,8 +22,8 @@ var SpiralLinux = OS{
 	ConfigFunction: createSpiralLinuxConfigs,
 }
 
-func createSpiralLinuxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
-	releases, numReleases, err := getBasicReleases(spiralLinuxMirror, spiralLinuxReleaseRe, 3)
+func createSpiralLinuxConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+	releases, numReleases, err := getBasicReleases(ctx, spiralLinuxMirror, spiralLinuxReleaseRe, 3)
 	if err != nil {
 		return nil, err
 	}
@@ -32,7 +33,7 @@ func createSpiralLinuxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 	for release := range releases {
 		go func() {
 			defer wg.Done()
-			configs, err := getSpiralLinuxConfigs(release, isoRe)
+			configs, err := getSpiralLinuxConfigs(ctx, release, isoRe)
 			if err != nil {
 				errs <- Failure{Release: release, Error: err}
 				return
@@ -45,9 +46,9 @@ func createSpiralLinuxConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 	return waitForConfigs(ch, wg), nil
 }
 
-func getSpiralLinuxConfigs(release string, isoRe *regexp.Regexp) (iter.Seq[Config], error) {
+func getSpiralLinuxConfigs(ctx context.Context, release string, isoRe *regexp.Regexp) (iter.Seq[Config], error) {
 	url := spiralLinuxMirror + release + "/"
-	page, err := web.CapturePage(url)
+	page, err := web.CapturePage(ctx, url)
 	if err != nil {
 		return nil, err
 	}
@@ -1,7 +1,7 @@
 package os
 
 import (
-	"errors"
+	"context"
 
 	"github.com/quickemu-project/quickget_configs/internal/web"
 )
@@ -16,9 +16,9 @@ var Tails = OS{
 	ConfigFunction: createTailsConfigs,
 }
 
-func createTailsConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createTailsConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	var data TailsData
-	if err := web.CapturePageToJson(tailsApi, &data); err != nil {
+	if err := web.CapturePageToJson(ctx, tailsApi, &data); err != nil {
 		return nil, err
 	}
 
@@ -27,7 +27,7 @@ func createTailsConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 		release := installation.Version
 		installationPath := findTailsIso(installation.InstallationPaths)
 		if len(installationPath.TargetFiles) == 0 {
-			errs <- Failure{Release: release, Error: errors.New("List of target files is empty")}
+			errs <- Failure{Release: release, Error: noMatch("List of target files is empty")}
 			continue
 		}
 		var sources []Source
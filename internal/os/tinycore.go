@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"regexp"
 
 	"github.com/quickemu-project/quickget_configs/internal/cs"
@@ -22,8 +23,8 @@ var TinyCore = OS{
 	ConfigFunction: createTinyCoreConfigs,
 }
 
-func createTinyCoreConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
-	releases, err := getSortedReleasesFunc(tinyCoreDownloadPageUrl, tinyCoreReleaseRe, 3, semverCompare)
+func createTinyCoreConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+	releases, err := getSortedReleasesFunc(ctx, tinyCoreDownloadPageUrl, tinyCoreReleaseRe, 3, semverCompare)
 	if err != nil {
 		return nil, err
 	}
@@ -36,7 +37,7 @@ func createTinyCoreConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 		for _, arch := range []string{"x86", "x86_64"} {
 			wg.Go(func() {
 				url := tinyCoreMirror + release + ".x/" + arch + "/release/"
-				page, err := web.CapturePage(url)
+				page, err := web.CapturePage(ctx, url)
 				if err != nil {
 					errs <- Failure{Release: release, Error: err}
 					return
@@ -47,7 +48,7 @@ func createTinyCoreConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 					wg.Go(func() {
 						url := url + match[1]
 						edition := match[2]
-						checksum, err := cs.SingleWhitespace(url + ".md5.txt")
+						checksum, err := cs.SingleWhitespace(ctx, url+".md5.txt")
 						if err != nil {
 							csErrs <- Failure{Release: release, Edition: edition, Error: err}
 						}
@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"regexp"
 	"strings"
 
@@ -21,8 +22,8 @@ var Trisquel = OS{
 	ConfigFunction: createTrisquelConfigs,
 }
 
-func createTrisquelConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
-	page, err := web.CapturePage(trisquelMirror)
+func createTrisquelConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+	page, err := web.CapturePage(ctx, trisquelMirror)
 	if err != nil {
 		return nil, err
 	}
@@ -38,7 +39,7 @@ func createTrisquelConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 			release := match[3]
 			edition := friendlyTrisquelEdition(match[2])
 
-			cs, err := cs.SingleWhitespace(url + ".sha256")
+			cs, err := cs.SingleWhitespace(ctx, url+".sha256")
 			if err != nil {
 				csErrs <- Failure{Release: release, Edition: edition, Error: err}
 			}
@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"regexp"
 	"slices"
 
@@ -18,10 +19,16 @@ var TrueNAS = OS{
 	Homepage:       "https://www.truenas.com/truenas-community-edition/",
 	Description:    "TrueNAS is the world’s most popular storage OS because it’s the only universal and unified data platform, giving you the freedom to use file, block, or object storage plus container and VM support to scale according to your needs.",
 	ConfigFunction: createTrueNASConfigs,
+	// The installer refuses to continue with less than 8 GiB of memory
+	Hardware: &Hardware{
+		MinRAM:         8 * gib,
+		RecommendedRAM: 16 * gib,
+		DiskSize:       32 * gib,
+	},
 }
 
-func createTrueNASConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
-	page, err := web.CapturePage(truenasMirror)
+func createTrueNASConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+	page, err := web.CapturePage(ctx, truenasMirror)
 	if err != nil {
 		return nil, err
 	}
@@ -46,7 +53,7 @@ func createTrueNASConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 			release := match[3]
 
 			// Checksums can either contain a SHA256 and nothing else, or a SHA256 and filename. We'll account for it with this manual length check (sha256 is 64 characters)
-			cs, err := web.CapturePage(url + ".sha256")
+			cs, err := web.CapturePage(ctx, url+".sha256")
 			if err != nil {
 				csErrs <- Failure{Release: release, Error: err}
 			}
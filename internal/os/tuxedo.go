@@ -1,6 +1,10 @@
 package os
 
-import "github.com/quickemu-project/quickget_configs/internal/cs"
+import (
+	"context"
+
+	"github.com/quickemu-project/quickget_configs/internal/cs"
+)
 
 const (
 	tuxedoMirror      = "https://os.tuxedocomputers.com/"
@@ -15,10 +19,10 @@ var Tuxedo = OS{
 	ConfigFunction: createTuxedoConfigs,
 }
 
-func createTuxedoConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createTuxedoConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	url := tuxedoMirror + tuxedoIsoFilename
 	csUrl := tuxedoMirror + "checksums/" + tuxedoIsoFilename + ".sha256"
-	cs, err := cs.SingleWhitespace(csUrl)
+	cs, err := cs.SingleWhitespace(ctx, csUrl)
 	if err != nil {
 		csErrs <- Failure{Error: err}
 	}
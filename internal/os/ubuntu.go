@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"sync"
@@ -11,15 +12,18 @@ import (
 	"github.com/quickemu-project/quickget_configs/pkg/quickgetdata"
 )
 
-const launchpadReleasesUrl = "https://api.launchpad.net/devel/ubuntu/series"
+const (
+	launchpadReleasesUrl = "https://api.launchpad.net/devel/ubuntu/series"
+	ubuntuCloudMirror    = "https://cloud-images.ubuntu.com/releases/"
+)
 
 var Edubuntu = OS{
 	Name:        "edubuntu",
 	PrettyName:  "Edubuntu",
 	Homepage:    "https://www.edubuntu.org/",
 	Description: "Stable, secure and privacy concious option for schools.",
-	ConfigFunction: func(errs, csErrs chan<- Failure) ([]Config, error) {
-		return getUbuntuConfigs("edubuntu", x86_64_only[:], errs, csErrs)
+	ConfigFunction: func(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+		return getUbuntuConfigs(ctx, "edubuntu", x86_64_only[:], errs, csErrs)
 	},
 }
 
@@ -28,8 +32,8 @@ var Kubuntu = OS{
 	PrettyName:  "Kubuntu",
 	Homepage:    "https://kubuntu.org/",
 	Description: "Free, complete, and open-source alternative to Microsoft Windows and Mac OS X which contains everything you need to work, play, or share.",
-	ConfigFunction: func(errs, csErrs chan<- Failure) ([]Config, error) {
-		return getUbuntuConfigs("kubuntu", x86_64_only[:], errs, csErrs)
+	ConfigFunction: func(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+		return getUbuntuConfigs(ctx, "kubuntu", x86_64_only[:], errs, csErrs)
 	},
 }
 
@@ -38,8 +42,8 @@ var Lubuntu = OS{
 	PrettyName:  "Lubuntu",
 	Homepage:    "https://lubuntu.me/",
 	Description: "Complete Operating System that ships the essential apps and services for daily use: office applications, PDF reader, image editor, music and video players, etc.",
-	ConfigFunction: func(errs, csErrs chan<- Failure) ([]Config, error) {
-		return getUbuntuConfigs("lubuntu", x86_64_only[:], errs, csErrs)
+	ConfigFunction: func(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+		return getUbuntuConfigs(ctx, "lubuntu", x86_64_only[:], errs, csErrs)
 	},
 }
 
@@ -48,16 +52,28 @@ var Ubuntu = OS{
 	PrettyName:  "Ubuntu",
 	Homepage:    "https://www.ubuntu.com/",
 	Description: "Complete desktop Linux operating system, freely available with both community and professional support.",
-	ConfigFunction: func(errs, csErrs chan<- Failure) ([]Config, error) {
-		return getUbuntuConfigs("ubuntu", x86_64_aarch64[:], errs, csErrs)
+	ConfigFunction: func(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+		return getUbuntuConfigs(ctx, "ubuntu", x86_64_aarch64[:], errs, csErrs)
 	},
 }
 
-var getUbuntuReleases = sync.OnceValues(fetchUbuntuReleases)
+var (
+	ubuntuReleasesOnce sync.Once
+	ubuntuReleases     []string
+	ubuntuReleasesErr  error
+)
 
-func fetchUbuntuReleases() ([]string, error) {
+// Fetches the supported releases once per run, shared by every flavour. The requests count towards whichever OS asks first
+func getUbuntuReleases(ctx context.Context) ([]string, error) {
+	ubuntuReleasesOnce.Do(func() {
+		ubuntuReleases, ubuntuReleasesErr = fetchUbuntuReleases(ctx)
+	})
+	return ubuntuReleases, ubuntuReleasesErr
+}
+
+func fetchUbuntuReleases(ctx context.Context) ([]string, error) {
 	var entries launchpadContents
-	if err := web.CapturePageToJson(launchpadReleasesUrl, &entries); err != nil {
+	if err := web.CapturePageToJson(ctx, launchpadReleasesUrl, &entries); err != nil {
 		return nil, err
 	}
 
@@ -76,8 +92,8 @@ var UbuntuBudgie = OS{
 	PrettyName:  "Ubuntu Budgie",
 	Homepage:    "https://ubuntubudgie.org/",
 	Description: "Community developed distribution, integrating the Budgie Desktop Environment with Ubuntu at its core.",
-	ConfigFunction: func(errs, csErrs chan<- Failure) ([]Config, error) {
-		return getUbuntuConfigs("ubuntu-budgie", x86_64_only[:], errs, csErrs)
+	ConfigFunction: func(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+		return getUbuntuConfigs(ctx, "ubuntu-budgie", x86_64_only[:], errs, csErrs)
 	},
 }
 
@@ -86,8 +102,8 @@ var UbuntuCinnamon = OS{
 	PrettyName:  "Ubuntu Cinnamon",
 	Homepage:    "https://ubuntucinnamon.org/",
 	Description: "Community-driven, featuring Linux Mint's Cinnamon Desktop with Ubuntu at the core, packed fast and full of features, here is the most traditionally modern desktop you will ever love.",
-	ConfigFunction: func(errs, csErrs chan<- Failure) ([]Config, error) {
-		return getUbuntuConfigs("ubuntucinnamon", x86_64_only[:], errs, csErrs)
+	ConfigFunction: func(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+		return getUbuntuConfigs(ctx, "ubuntucinnamon", x86_64_only[:], errs, csErrs)
 	},
 }
 
@@ -96,8 +112,8 @@ var UbuntuKylin = OS{
 	PrettyName:  "Ubuntu Kylin",
 	Homepage:    "https://www.ubuntukylin.com/",
 	Description: "Universal desktop operating system for personal computers, laptops, and embedded devices. It is dedicated to bringing a smarter user experience to users all over the world.",
-	ConfigFunction: func(errs, csErrs chan<- Failure) ([]Config, error) {
-		return getUbuntuConfigs("ubuntukylin", x86_64_only[:], errs, csErrs)
+	ConfigFunction: func(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+		return getUbuntuConfigs(ctx, "ubuntukylin", x86_64_only[:], errs, csErrs)
 	},
 }
 
@@ -106,8 +122,8 @@ var UbuntuMATE = OS{
 	PrettyName:  "Ubuntu MATE",
 	Homepage:    "https://ubuntu-mate.org/",
 	Description: "Stable, easy-to-use operating system with a configurable desktop environment. It is ideal for those who want the most out of their computers and prefer a traditional desktop metaphor.",
-	ConfigFunction: func(errs, csErrs chan<- Failure) ([]Config, error) {
-		return getUbuntuConfigs("ubuntu-mate", x86_64_only[:], errs, csErrs)
+	ConfigFunction: func(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+		return getUbuntuConfigs(ctx, "ubuntu-mate", x86_64_only[:], errs, csErrs)
 	},
 }
 
@@ -116,8 +132,8 @@ var UbuntuServer = OS{
 	PrettyName:  "Ubuntu Server",
 	Homepage:    "https://www.ubuntu.com/server",
 	Description: "Brings economic and technical scalability to your datacentre, public or private. Whether you want to deploy an OpenStack cloud, a Kubernetes cluster or a 50,000-node render farm, Ubuntu Server delivers the best value scale-out performance available.",
-	ConfigFunction: func(errs, csErrs chan<- Failure) ([]Config, error) {
-		return getUbuntuConfigs("ubuntu-server", three_architectures[:], errs, csErrs)
+	ConfigFunction: func(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+		return getUbuntuConfigs(ctx, "ubuntu-server", three_architectures[:], errs, csErrs)
 	},
 }
 
@@ -126,8 +142,8 @@ var UbuntuStudio = OS{
 	PrettyName:  "Ubuntu Studio",
 	Homepage:    "https://ubuntustudio.org/",
 	Description: "Comes preinstalled with a selection of the most common free multimedia applications available, and is configured for best performance for various purposes: Audio, Graphics, Video, Photography and Publishing.",
-	ConfigFunction: func(errs, csErrs chan<- Failure) ([]Config, error) {
-		return getUbuntuConfigs("ubuntustudio", x86_64_only[:], errs, csErrs)
+	ConfigFunction: func(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+		return getUbuntuConfigs(ctx, "ubuntustudio", x86_64_only[:], errs, csErrs)
 	},
 }
 
@@ -136,8 +152,8 @@ var UbuntuUnity = OS{
 	PrettyName:  "Ubuntu Unity",
 	Homepage:    "https://ubuntuunity.org/",
 	Description: "Flavor of Ubuntu featuring the Unity7 desktop environment (the default desktop environment used by Ubuntu from 2010-2017).",
-	ConfigFunction: func(errs, csErrs chan<- Failure) ([]Config, error) {
-		return getUbuntuConfigs("ubuntu-unity", x86_64_only[:], errs, csErrs)
+	ConfigFunction: func(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+		return getUbuntuConfigs(ctx, "ubuntu-unity", x86_64_only[:], errs, csErrs)
 	},
 }
 
@@ -146,8 +162,8 @@ var Xubuntu = OS{
 	PrettyName:  "Xubuntu",
 	Homepage:    "https://xubuntu.org/",
 	Description: "Elegant and easy to use operating system. Xubuntu comes with Xfce, which is a stable, light and configurable desktop environment.",
-	ConfigFunction: func(errs, csErrs chan<- Failure) ([]Config, error) {
-		return getUbuntuConfigs("xubuntu", x86_64_only[:], errs, csErrs)
+	ConfigFunction: func(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
+		return getUbuntuConfigs(ctx, "xubuntu", x86_64_only[:], errs, csErrs)
 	},
 }
 
@@ -158,8 +174,8 @@ type launchpadContents struct {
 	} `json:"entries"`
 }
 
-func getUbuntuConfigs(variant string, architectures []Arch, errs, csErrs chan<- Failure) ([]Config, error) {
-	releases, err := getUbuntuReleases()
+func getUbuntuConfigs(ctx context.Context, variant string, architectures []Arch, errs, csErrs chan<- Failure) ([]Config, error) {
+	releases, err := getUbuntuReleases(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -168,28 +184,37 @@ func getUbuntuConfigs(variant string, architectures []Arch, errs, csErrs chan<-
 	for _, release := range releases {
 		for _, arch := range architectures {
 			wg.Go(func() {
-				config, err, csErr := getUbuntuConfig(release, variant, arch)
+				configs, err, csErr := getUbuntuConfig(ctx, release, variant, arch)
 				if err != nil {
 					errs <- Failure{Release: release, Arch: arch, Error: err}
 				}
 				if csErr != nil {
 					csErrs <- Failure{Release: release, Arch: arch, Error: csErr}
 				}
-				if config != nil {
-					ch <- *config
+				for _, config := range configs {
+					ch <- config
 				}
 			})
+			if variant == "ubuntu-server" && release != "daily-live" {
+				wg.Go(func() {
+					config, csErr := getUbuntuCloudConfig(ctx, release, arch)
+					if csErr != nil {
+						csErrs <- Failure{Release: release, Edition: "cloud", Arch: arch, Error: csErr}
+					}
+					ch <- config
+				})
+			}
 		}
 	}
 
 	return waitForConfigs(ch, wg), nil
 }
 
-func getUbuntuConfig(release string, variant string, arch Arch) (config *Config, err error, csErr error) {
+func getUbuntuConfig(ctx context.Context, release string, variant string, arch Arch) (configs []Config, err error, csErr error) {
 	c := mirror.HttpClient{}
 	url := getUbuntuUrl(release, variant, arch)
 
-	head, err := c.ReadDir(url)
+	head, err := c.ReadDir(ctx, url)
 	if err != nil {
 		return nil, err, nil
 	}
@@ -198,7 +223,7 @@ func getUbuntuConfig(release string, variant string, arch Arch) (config *Config,
 	// Exclude checksums for daily live releases, which refresh too fast for checksums to be reliably reported
 	if release != "daily-live" {
 		if f, ok := head.Files["SHA256SUMS"]; ok {
-			checksums, csErr = cs.Build(cs.Whitespace, f)
+			checksums, csErr = cs.Build(ctx, cs.Whitespace, f)
 		}
 	}
 
@@ -214,7 +239,7 @@ func getUbuntuConfig(release string, variant string, arch Arch) (config *Config,
 
 	checksum := checksums["*"+f.Name]
 
-	config = &Config{
+	config := Config{
 		Release: release,
 		Arch:    arch,
 	}
@@ -230,10 +255,75 @@ func getUbuntuConfig(release string, variant string, arch Arch) (config *Config,
 			webSource(f.URL.String(), checksum, "", f.Name),
 		}
 	}
+	configs = append(configs, config)
+
+	// Server releases publish a netboot kernel which installs from the ISO over HTTP
+	if d, ok := head.SubDirs["netboot"]; ok && variant == "ubuntu-server" {
+		netboot, err := getUbuntuNetbootConfig(ctx, d, config, f.URL.String())
+		if err != nil {
+			return configs, err, csErr
+		}
+		if netboot != nil {
+			configs = append(configs, *netboot)
+		}
+	}
 
 	return
 }
 
+func getUbuntuNetbootConfig(ctx context.Context, d mirror.SubDirEntry, config Config, isoUrl string) (*Config, error) {
+	netboot, err := d.Fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	archDir, ok := netboot.SubDirs[strings.TrimSuffix(getUbuntuArchSuffix(config.Arch), ".iso")]
+	if !ok {
+		return nil, nil
+	}
+	contents, err := archDir.Fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	kernel, kernelOk := contents.Files["linux"]
+	initrd, initrdOk := contents.Files["initrd"]
+	if !kernelOk || !initrdOk {
+		return nil, nil
+	}
+
+	return &Config{
+		Release: config.Release,
+		Edition: "netboot",
+		GuestOS: config.GuestOS,
+		Arch:    config.Arch,
+		KernelBoot: []Source{
+			kernelSource(
+				WebSource{URL: kernel.URL.String()},
+				[]WebSource{{URL: initrd.URL.String()}},
+				"ip=dhcp url="+isoUrl,
+			),
+		},
+	}, nil
+}
+
+func getUbuntuCloudConfig(ctx context.Context, release string, arch Arch) (Config, error) {
+	cloudMirror := fmt.Sprintf("%s%s/release/", ubuntuCloudMirror, release)
+	img := fmt.Sprintf("ubuntu-%s-server-cloudimg-%s.img", release, strings.Split(getUbuntuArchSuffix(arch), ".")[0])
+	checksums, err := cs.Build(ctx, cs.Whitespace, cloudMirror+"SHA256SUMS")
+
+	return Config{
+		Release: release,
+		Edition: "cloud",
+		Arch:    arch,
+		DiskImages: []Disk{
+			{
+				Source: webSource(cloudMirror+img, checksums["*"+img], "", img),
+				Format: quickgetdata.Qcow2,
+			},
+		},
+		CloudInit: true,
+	}, err
+}
+
 func getUbuntuSku(variant string) string {
 	switch variant {
 	case "ubuntu-server":
@@ -1,15 +1,16 @@
 package os
 
 import (
+	"context"
 	"regexp"
 	"strings"
 
 	"github.com/quickemu-project/quickget_configs/internal/cs"
-	"github.com/quickemu-project/quickget_configs/internal/web"
+	"github.com/quickemu-project/quickget_configs/internal/forge"
 )
 
 const (
-	vanillaAPI        = "https://api.github.com/repos/Vanilla-OS/live-iso/releases"
+	vanillaRepo       = "Vanilla-OS/live-iso"
 	vanillaRevisionRe = `([\d\.]+)-r[\d\.]+`
 )
 
@@ -21,25 +22,28 @@ var VanillaOS = OS{
 	ConfigFunction: createVanillaOSConfigs,
 }
 
-func createVanillaOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
-	var apiData []GithubAPI
-	if err := web.CapturePageToJson(vanillaAPI, &apiData); err != nil {
-		return nil, err
-	}
-
+func createVanillaOSConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	revisionRe := regexp.MustCompile(vanillaRevisionRe)
 	usedReleases := make(map[string]struct{})
 	ch, wg := getChannels()
 
-	for _, entry := range apiData {
+	for entry, err := range forge.GitHub.Releases(ctx, vanillaRepo) {
+		if err != nil {
+			// Releases from earlier pages are still usable
+			if len(usedReleases) == 0 {
+				return nil, err
+			}
+			errs <- Failure{Error: err}
+			break
+		}
 		if len(usedReleases) >= 3 {
 			break
 		}
-		release := getVanillaOSRelease(entry.TagName, revisionRe)
+		release := getVanillaOSRelease(entry.Tag, revisionRe)
 		if _, used := usedReleases[release]; used {
 			continue
 		}
-		var isoAsset *GithubAsset
+		var isoAsset *forge.Asset
 		var checksumUrl string
 		for _, asset := range entry.Assets {
 			switch {
@@ -56,12 +60,16 @@ func createVanillaOSConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 		usedReleases[release] = struct{}{}
 
 		wg.Go(func() {
-			var checksum string
+			checksum := isoAsset.Sha256()
 			if checksumUrl != "" {
-				var err error
-				checksum, err = cs.SingleWhitespace(checksumUrl)
+				published, err := cs.SingleWhitespace(ctx, checksumUrl)
 				if err != nil {
 					csErrs <- Failure{Release: release, Error: err}
+				} else {
+					if err := cs.Verify(published, checksum); err != nil {
+						csErrs <- Failure{Release: release, Error: err}
+					}
+					checksum = published
 				}
 			}
 			ch <- Config{
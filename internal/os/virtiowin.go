@@ -0,0 +1,106 @@
+package os
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"slices"
+	"sync"
+
+	"github.com/quickemu-project/quickget_configs/internal/cs"
+	"github.com/quickemu-project/quickget_configs/internal/web"
+)
+
+const virtioWinMirror = "https://fedorapeople.org/groups/virt/virtio-win/direct-downloads/"
+
+var (
+	virtioWinIsoRe      = regexp.MustCompile(`href="(virtio-win-[\d\.]+\.iso)"`)
+	virtioWinChecksumRe = regexp.MustCompile(`href="(virtio-win-[\d\.]+\.iso\.sha256)"`)
+)
+
+// The driver ISOs of the stable and latest channels, shared by every Windows-like OS
+type virtioWinMedia struct {
+	sources []Source
+	err     error
+	csErr   error
+}
+
+var (
+	virtioWinOnce   sync.Once
+	virtioWinResult virtioWinMedia
+)
+
+// Fetches the VirtIO drivers for Windows guests once per run. The requests count towards whichever OS asks first
+func getVirtioWinDrivers(ctx context.Context) virtioWinMedia {
+	virtioWinOnce.Do(func() {
+		virtioWinResult = fetchVirtioWinDrivers(ctx)
+	})
+	return virtioWinResult
+}
+
+// The stable build is listed first, followed by the latest build if it's a different version, for guests which need newer drivers
+func fetchVirtioWinDrivers(ctx context.Context) virtioWinMedia {
+	var media virtioWinMedia
+	var errs, csErrs []error
+	for _, channel := range [...]string{"stable-virtio", "latest-virtio"} {
+		source, csErr, err := getVirtioWinIso(ctx, channel)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if csErr != nil {
+			csErrs = append(csErrs, csErr)
+		}
+		if !slices.ContainsFunc(media.sources, func(s Source) bool { return s.Web.FileName == source.Web.FileName }) {
+			media.sources = append(media.sources, source)
+		}
+	}
+	media.err, media.csErr = errors.Join(errs...), errors.Join(csErrs...)
+	return media
+}
+
+// Returns the channel's ISO. Checksums aren't published alongside every build, in which case the source has none and csErr is set
+func getVirtioWinIso(ctx context.Context, channel string) (source Source, csErr error, err error) {
+	mirror := virtioWinMirror + channel + "/"
+	page, err := web.CapturePage(ctx, mirror)
+	if err != nil {
+		return Source{}, nil, err
+	}
+	match := virtioWinIsoRe.FindStringSubmatch(page)
+	if match == nil {
+		return Source{}, nil, noMatch("could not find virtio-win ISO in %s", mirror)
+	}
+	iso := match[1]
+
+	var checksum string
+	if csMatch := virtioWinChecksumRe.FindStringSubmatch(page); csMatch != nil {
+		checksum, csErr = cs.SingleWhitespace(ctx, mirror+csMatch[1])
+	} else {
+		csErr = missingChecksum("no checksum published for %s", iso)
+	}
+	return webSource(mirror+iso, checksum, "", iso), csErr, nil
+}
+
+// Returns a function which copies the driver media to attach to a Windows-like guest, so no two configs share a source.
+// Failing to find it is reported, but doesn't prevent the configs from being published
+func virtioWinDrivers(ctx context.Context, errs, csErrs chan<- Failure) func() []Source {
+	media := getVirtioWinDrivers(ctx)
+	if media.err != nil {
+		errs <- Failure{Edition: "virtio-win", Error: media.err}
+	}
+	if media.csErr != nil {
+		csErrs <- Failure{Edition: "virtio-win", Error: media.csErr}
+	}
+	return func() []Source {
+		sources := make([]Source, len(media.sources))
+		for i, source := range media.sources {
+			if source.Web != nil {
+				copied := *source.Web
+				copied.Mirrors = slices.Clone(copied.Mirrors)
+				source.Web = &copied
+			}
+			sources[i] = source
+		}
+		return sources
+	}
+}
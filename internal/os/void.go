@@ -1,6 +1,7 @@
 package os
 
 import (
+	"context"
 	"regexp"
 	"strings"
 
@@ -10,7 +11,7 @@ import (
 
 const (
 	voidMirror = "https://repo-default.voidlinux.org/live/"
-	voidIsoRe  = `^void-live-(aarch64|x86_64)(-musl)?-\d{8}-(.*?)\.iso$`
+	voidIsoRe  = `^void-live-(aarch64|x86_64|i686)(-musl)?-\d{8}-(.*?)\.iso$`
 )
 
 var Void = OS{
@@ -21,9 +22,9 @@ var Void = OS{
 	ConfigFunction: createVoidConfigs,
 }
 
-func createVoidConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createVoidConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	c := mirror.LegacyHttpClient{}
-	head, err := c.ReadDir(voidMirror)
+	head, err := c.ReadDir(ctx, voidMirror)
 	if err != nil {
 		return nil, err
 	}
@@ -39,7 +40,7 @@ func createVoidConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 	for _, d := range releases {
 		release := d.Name
 		wg.Go(func() {
-			contents, err := d.Fetch()
+			contents, err := d.Fetch(ctx)
 			if err != nil {
 				errs <- Failure{Release: release, Error: err}
 				return
@@ -47,7 +48,7 @@ func createVoidConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 
 			checksums := make(map[string]string)
 			if f, ok := contents.Files["sha256sum.txt"]; ok {
-				checksums, err = cs.Build(cs.Sha256Regex, f)
+				checksums, err = cs.Build(ctx, cs.Sha256Regex, f)
 				if err != nil {
 					csErrs <- Failure{Release: release, Error: err}
 				}
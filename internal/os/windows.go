@@ -1,10 +1,13 @@
 package os
 
 import (
+	"context"
 	"errors"
 	"regexp"
+	"strings"
 
 	"github.com/quickemu-project/quickget_configs/internal/web"
+	"github.com/quickemu-project/quickget_configs/pkg/quickgetdata"
 )
 
 const (
@@ -20,12 +23,13 @@ var Windows = OS{
 	ConfigFunction: createWindowsConfigs,
 }
 
-func createWindowsConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createWindowsConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	var list []OsListData
-	if err := web.CapturePageToJson(windowsRedirectMirror+"list?os=windows", &list); err != nil {
+	if err := web.CapturePageToJson(ctx, windowsRedirectMirror+"list?os=windows", &list); err != nil {
 		return nil, err
 	}
 
+	drivers := virtioWinDrivers(ctx, errs, csErrs)
 	var configs []Config
 	for _, data := range list {
 		if data.Error != "" {
@@ -36,10 +40,16 @@ func createWindowsConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 		configs = append(configs, Config{
 			Release: data.Release,
 			Edition: data.Edition,
+			GuestOS: quickgetdata.Windows,
 			Arch:    data.Arch,
 			ISO: []Source{
 				webSource(url, data.Checksum, "", data.Filename),
 			},
+			FixedISO: drivers(),
+			Hardware: getWindowsHardware(data.Release),
+			// The ISOs are served through a redirect service which requests a download link from Microsoft each time, so they aren't validated.
+			// Skipping also covers the drivers, which is safe since their URL was read from the mirror's listing during this run,
+			// and the same URL is validated as part of the Windows Server and ReactOS configs
 			Validation: Validation{Skip: true},
 		})
 	}
@@ -47,6 +57,21 @@ func createWindowsConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 	return configs, nil
 }
 
+// Windows 11 refuses to install without Secure Boot and TPM 2.0, and raises the minimum memory and disk requirements
+func getWindowsHardware(release string) *Hardware {
+	if !strings.HasPrefix(release, "11") {
+		return nil
+	}
+	secureBoot := true
+	return &Hardware{
+		MinRAM:     4 * gib,
+		DiskSize:   64 * gib,
+		Firmware:   quickgetdata.EFI,
+		SecureBoot: &secureBoot,
+		TPM:        quickgetdata.TPM2_0,
+	}
+}
+
 type OsListData struct {
 	Release  string `json:"release"`
 	Edition  string `json:"edition"`
@@ -65,16 +90,17 @@ var WindowsServer = OS{
 	ConfigFunction: createWindowsServerConfigs,
 }
 
-func createWindowsServerConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createWindowsServerConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	releases := [...]string{"2025", "2022", "2019", "2016"}
 	isoRe := regexp.MustCompile(`scope="row"> (.*?) <\/th>.*?ISO.*?data-target="(https:.*?)"`)
+	drivers := virtioWinDrivers(ctx, errs, csErrs)
 	ch, wg := getChannelsWith(len(releases))
 
 	for _, release := range releases {
 		go func() {
 			defer wg.Done()
 			mirror := windowsServerMirror + "-" + release + "/"
-			page, err := web.CapturePage(mirror)
+			page, err := web.CapturePage(ctx, mirror)
 			if err != nil {
 				errs <- Failure{Release: release, Error: err}
 				return
@@ -85,9 +111,11 @@ func createWindowsServerConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 				ch <- Config{
 					Release: release,
 					Edition: edition,
+					GuestOS: quickgetdata.WindowsServer,
 					ISO: []Source{
 						urlSource(url),
 					},
+					FixedISO: drivers(),
 				}
 			}
 		}()
@@ -1,7 +1,7 @@
 package os
 
 import (
-	"errors"
+	"context"
 	"strconv"
 	"strings"
 
@@ -23,9 +23,9 @@ var Zorin = OS{
 	ConfigFunction: createZorinConfigs,
 }
 
-func createZorinConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
+func createZorinConfigs(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error) {
 	c := mirror.LegacyHttpClient{}
-	head, err := c.ReadDir(zorinReleaseMirror)
+	head, err := c.ReadDir(ctx, zorinReleaseMirror)
 	if err != nil {
 		return nil, err
 	}
@@ -42,7 +42,7 @@ func createZorinConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 			release := releaseDir.Name
 
 			checksums := make(map[string]string)
-			contents, err := releaseDir.Fetch()
+			contents, err := releaseDir.Fetch(ctx)
 			// Directory contents are only used for checksums in this case
 			if err != nil {
 				csErrs <- Failure{Release: release, Error: err}
@@ -50,7 +50,7 @@ func createZorinConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 				for k, f := range contents.Files {
 					k = strings.ToLower(k)
 					if strings.HasSuffix(k, ".txt") && strings.Contains(k, "sum") {
-						checksums, err = cs.Build(cs.Whitespace, f.URL)
+						checksums, err = cs.Build(ctx, cs.Whitespace, f.URL)
 						if err != nil {
 							csErrs <- Failure{Release: release, Error: err}
 						} else {
@@ -63,14 +63,14 @@ func createZorinConfigs(errs, csErrs chan<- Failure) ([]Config, error) {
 			for _, edition := range zorinEditions {
 				url := zorinMirror + release + edition + "64"
 
-				finalUrl, err := web.FinalRedirectUrl(url)
+				finalUrl, err := web.FinalRedirectUrl(ctx, url)
 				if err != nil {
 					errs <- Failure{Release: release, Error: err}
 					continue
 				}
 				fields := strings.Split(finalUrl, "/")
 				if len(fields) == 0 {
-					errs <- Failure{Release: release, Error: errors.New("final url has no fields")}
+					errs <- Failure{Release: release, Error: noMatch("final url has no fields")}
 					continue
 				}
 				filename := fields[len(fields)-1]
@@ -0,0 +1,197 @@
+package regression
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+	"github.com/quickemu-project/quickget_configs/internal/data"
+	qgdata "github.com/quickemu-project/quickget_configs/pkg/quickgetdata"
+)
+
+// Reads the data published by a previous run, decompressing it if the file name ends with .gz or .zst
+func Load(path string) ([]qgdata.OSData, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var r io.Reader = bytes.NewReader(contents)
+	switch {
+	case strings.HasSuffix(path, ".gz"):
+		if r, err = gzip.NewReader(r); err != nil {
+			return nil, err
+		}
+	case strings.HasSuffix(path, ".zst"):
+		dec, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		defer dec.Close()
+		r = dec
+	}
+	var distros []qgdata.OSData
+	if err := json.NewDecoder(r).Decode(&distros); err != nil {
+		return nil, fmt.Errorf("Invalid data in %s: %w", path, err)
+	}
+	return distros, nil
+}
+
+// Returns the regressions of each OS since the previous run, sorted by OS and kind.
+// An OS missing from the current run has failed, which is reported as a failure rather than a regression
+func Compare(previous, current []qgdata.OSData) []data.Regression {
+	previousByName := make(map[string]qgdata.OSData, len(previous))
+	for _, os := range previous {
+		previousByName[os.Name] = os
+	}
+	var regressions []data.Regression
+	for _, os := range current {
+		if before, ok := previousByName[os.Name]; ok {
+			regressions = append(regressions, compareOS(before, os)...)
+		}
+	}
+	slices.SortStableFunc(regressions, func(a, b data.Regression) int {
+		return cmp.Or(
+			strings.Compare(a.OS, b.OS),
+			cmp.Compare(slices.Index(data.RegressionKinds, a.Kind), slices.Index(data.RegressionKinds, b.Kind)),
+		)
+	})
+	return regressions
+}
+
+type configKey struct {
+	Release, Edition string
+	Arch             qgdata.Arch
+}
+
+func keyOf(config qgdata.Config) configKey {
+	arch := config.Arch
+	if arch == "" {
+		arch = qgdata.X86_64
+	}
+	return configKey{Release: config.Release, Edition: config.Edition, Arch: arch}
+}
+
+func compareOS(before, after qgdata.OSData) []data.Regression {
+	var regressions []data.Regression
+	add := func(kind data.RegressionKind, key configKey, format string, a ...any) {
+		regressions = append(regressions, data.Regression{
+			OS:      after.Name,
+			Kind:    kind,
+			Release: key.Release,
+			Edition: key.Edition,
+			Arch:    key.Arch,
+			Message: fmt.Sprintf(format, a...),
+		})
+	}
+
+	releaseOf := func(k configKey) string { return k.Release }
+	releasesBefore, releasesAfter := collect(before.Releases, releaseOf), collect(after.Releases, releaseOf)
+	// Old releases are expected to be replaced by new ones, so only a net loss counts
+	if len(releasesAfter) < len(releasesBefore) {
+		add(data.LostReleases, configKey{}, "%d releases, down from %d. Missing: %s",
+			len(releasesAfter), len(releasesBefore), strings.Join(missing(releasesBefore, releasesAfter), ", "))
+	}
+	archOf := func(k configKey) qgdata.Arch { return k.Arch }
+	for _, arch := range missing(collect(before.Releases, archOf), collect(after.Releases, archOf)) {
+		add(data.LostArch, configKey{Arch: arch}, "No configs for %s", arch)
+	}
+	editionOf := func(k configKey) string { return k.Edition }
+	for _, edition := range missing(collect(before.Releases, editionOf), collect(after.Releases, editionOf)) {
+		if edition != "" {
+			add(data.LostEdition, configKey{Edition: edition}, "No configs for the %s edition", edition)
+		}
+	}
+
+	previousConfigs := make(map[configKey]qgdata.Config, len(before.Releases))
+	for _, config := range before.Releases {
+		previousConfigs[keyOf(config)] = config
+	}
+	for _, config := range after.Releases {
+		key := keyOf(config)
+		previous, ok := previousConfigs[key]
+		if !ok {
+			continue
+		}
+		checksumsBefore, sourcesBefore := checksums(previous)
+		checksumsAfter, sourcesAfter := checksums(config)
+		if checksumsAfter < checksumsBefore {
+			add(data.LostChecksum, key, "%d of %d sources have checksums, down from %d of %d", checksumsAfter, sourcesAfter, checksumsBefore, sourcesBefore)
+		}
+		hostsBefore, hostsAfter := hosts(previous), hosts(config)
+		if len(hostsBefore) > 0 && len(hostsAfter) > 0 && !slices.Equal(hostsBefore, hostsAfter) {
+			add(data.HostChanged, key, "Served by %s, previously %s", strings.Join(hostsAfter, ", "), strings.Join(hostsBefore, ", "))
+		}
+	}
+	return regressions
+}
+
+// Returns the set of values fn takes for the configs
+func collect[T comparable](configs []qgdata.Config, fn func(configKey) T) map[T]struct{} {
+	set := make(map[T]struct{})
+	for _, config := range configs {
+		set[fn(keyOf(config))] = struct{}{}
+	}
+	return set
+}
+
+// Returns the values in before which aren't in after, sorted
+func missing[T cmp.Ordered](before, after map[T]struct{}) []T {
+	var values []T
+	for value := range before {
+		if _, ok := after[value]; !ok {
+			values = append(values, value)
+		}
+	}
+	slices.Sort(values)
+	return values
+}
+
+func webSources(config qgdata.Config) []qgdata.WebSource {
+	sources := slices.Concat(config.ISO, config.IMG, config.FixedISO, config.Floppy, config.KernelBoot)
+	for _, disk := range config.DiskImages {
+		sources = append(sources, disk.Source)
+	}
+	var webSources []qgdata.WebSource
+	for _, source := range sources {
+		switch {
+		case source.Web != nil:
+			webSources = append(webSources, *source.Web)
+		case source.Kernel != nil:
+			webSources = append(webSources, source.Kernel.Kernel)
+			webSources = append(webSources, source.Kernel.Initrd...)
+		}
+	}
+	return webSources
+}
+
+// Returns the number of web sources of the config with checksums, and the number of web sources
+func checksums(config qgdata.Config) (int, int) {
+	sources := webSources(config)
+	var n int
+	for _, source := range sources {
+		if source.Checksum != "" {
+			n++
+		}
+	}
+	return n, len(sources)
+}
+
+// Returns the hosts serving the config's web sources, sorted. Mirrors aren't included
+func hosts(config qgdata.Config) []string {
+	set := make(map[string]struct{})
+	for _, source := range webSources(config) {
+		if u, err := url.Parse(source.URL); err == nil && u.Hostname() != "" {
+			set[u.Hostname()] = struct{}{}
+		}
+	}
+	return slices.Sorted(maps.Keys(set))
+}
@@ -0,0 +1,11 @@
+package status
+
+import _ "embed"
+
+// The page's stylesheet and script are embedded in it, so it doesn't depend on anything being fetched when it's viewed
+var (
+	//go:embed assets/status.css
+	statusCSS string
+	//go:embed assets/status.js
+	statusJS string
+)
@@ -0,0 +1,54 @@
+// Filters the summary table and OS sections, and opens the section containing a linked release
+(() => {
+    const failedOnly = document.getElementById("filter-failed");
+    const arch = document.getElementById("filter-arch");
+    const guest = document.getElementById("filter-guest");
+    const search = document.getElementById("filter-search");
+    const count = document.getElementById("filter-count");
+
+    const hasWord = (list, word) => word === "" || list.split(" ").includes(word);
+
+    function apply() {
+        const query = search.value.trim().toLowerCase();
+        let shown = 0;
+        for (const row of document.querySelectorAll("tr[data-os]")) {
+            const matches =
+                (!failedOnly.checked || row.dataset.state !== "ok") &&
+                hasWord(row.dataset.arch, arch.value) &&
+                hasWord(row.dataset.guest, guest.value) &&
+                (query === "" || row.dataset.search.includes(query));
+            row.hidden = !matches;
+            const section = document.getElementById(row.dataset.os);
+            if (section) {
+                section.hidden = !matches;
+            }
+            if (matches) {
+                shown++;
+            }
+        }
+        for (const release of document.querySelectorAll(".release[data-arch]")) {
+            release.hidden = !hasWord(release.dataset.arch, arch.value) || !hasWord(release.dataset.guest, guest.value);
+        }
+        count.textContent = shown;
+    }
+
+    function openTarget() {
+        const id = decodeURIComponent(location.hash.slice(1));
+        const target = id && document.getElementById(id);
+        if (!target) {
+            return;
+        }
+        for (let details = target.closest("details"); details; details = details.parentElement.closest("details")) {
+            details.open = true;
+        }
+        target.hidden = false;
+        target.scrollIntoView();
+    }
+
+    for (const control of [failedOnly, arch, guest, search]) {
+        control.addEventListener("input", apply);
+    }
+    window.addEventListener("hashchange", openTarget);
+    apply();
+    openTarget();
+})();
@@ -0,0 +1,126 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// The state of an OS shown on its badge
+type badgeState string
+
+const (
+	badgePassing badgeState = "passing"
+	badgePartial badgeState = "partial"
+	badgeFailing badgeState = "failing"
+	// The OS has been failing for at least staleAfter, so the configs consumers last saw for it are out of date
+	badgeStale badgeState = "stale"
+)
+
+// How long an OS can fail before its badge shows it as stale. Without a history file, failing OSes are never stale
+const staleAfter = 7 * 24 * time.Hour
+
+var badgeColors = map[badgeState]string{
+	badgePassing: "#4c1",
+	badgePartial: "#dfb317",
+	badgeFailing: "#e05d44",
+	badgeStale:   "#9f9f9f",
+}
+
+// The states on the overall badge, in order
+var badgeStates = []badgeState{badgePassing, badgePartial, badgeFailing, badgeStale}
+
+const (
+	badgeDir = "badges"
+	// The file name of the badge summarising every OS, in the status page directory
+	overallBadge = "badge.svg"
+	// Padding either side of a badge's text
+	badgePadding = 6
+	// The approximate width of a character of 11px Verdana
+	badgeCharWidth = 7
+)
+
+// A badge in the style of shields.io, with a grey label and a coloured message
+type badge struct {
+	Label   string
+	Message string
+	Color   string
+}
+
+func textWidth(text string) int {
+	return utf8.RuneCountInString(text)*badgeCharWidth + 2*badgePadding
+}
+
+func (b badge) LabelWidth() int {
+	return textWidth(b.Label)
+}
+
+func (b badge) MessageWidth() int {
+	return textWidth(b.Message)
+}
+
+func (b badge) Width() int {
+	return b.LabelWidth() + b.MessageWidth()
+}
+
+func (s osStatus) badgeState(runStart time.Time) badgeState {
+	switch state := s.state(); {
+	case state == "ok":
+		return badgePassing
+	case state == "partial":
+		return badgePartial
+	case !s.FailingSince.IsZero() && runStart.Sub(s.FailingSince) >= staleAfter:
+		return badgeStale
+	default:
+		return badgeFailing
+	}
+}
+
+// The badge summarising every OS, such as "80 passing, 3 partial, 2 failing", coloured by the worst state present
+func (s *Status) overallBadge() badge {
+	counts := make(map[badgeState]int)
+	for _, os := range s.Data {
+		counts[os.badgeState(s.StartTime)]++
+	}
+	var parts []string
+	for _, state := range badgeStates {
+		if counts[state] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[state], state))
+		}
+	}
+	color := badgeColors[badgePassing]
+	if counts[badgeFailing] > 0 || counts[badgeStale] > 0 {
+		color = badgeColors[badgeFailing]
+	} else if counts[badgePartial] > 0 {
+		color = badgeColors[badgePartial]
+	}
+	return badge{Label: "quickget configs", Message: strings.Join(parts, ", "), Color: color}
+}
+
+// Writes a badge for each OS to badges/<name>.svg and the overall badge to badge.svg in the status page directory
+func (s *Status) writeBadges() error {
+	if err := os.Mkdir(statusPageDir+"/"+badgeDir, 0755); err != nil {
+		return err
+	}
+	for _, os := range s.Data {
+		state := os.badgeState(s.StartTime)
+		b := badge{Label: os.PrettyName, Message: string(state), Color: badgeColors[state]}
+		name := anchorUnsafe.ReplaceAllString(os.Name, "-") + ".svg"
+		if err := writeBadge(statusPageDir+"/"+badgeDir+"/"+name, b); err != nil {
+			return err
+		}
+	}
+	return writeBadge(statusPageDir+"/"+overallBadge, s.overallBadge())
+}
+
+func writeBadge(path string, b badge) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return badgeTempl(b).Render(context.Background(), file)
+}
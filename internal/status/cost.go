@@ -0,0 +1,65 @@
+package status
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/quickemu-project/quickget_configs/internal/data"
+)
+
+// The number of the most expensive OSes logged at the end of a run
+const costlyOSLogged = 10
+
+// Returns the OSes sorted by the time they took, then by the requests they made, the most expensive first
+func (s *Status) byCost() []osStatus {
+	sorted := slices.Clone(s.Data)
+	slices.SortStableFunc(sorted, func(a, b osStatus) int {
+		if c := cmp.Compare(b.Timing.Total(), a.Timing.Total()); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(b.Usage.Total().Requests, a.Usage.Total().Requests); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return sorted
+}
+
+// Returns the hosts sorted by the total time spent waiting for their responses, the slowest first
+func (s *Status) latencyByCost() []data.HostLatency {
+	sorted := slices.Clone(s.Latency)
+	slices.SortStableFunc(sorted, func(a, b data.HostLatency) int {
+		if c := cmp.Compare(b.Total, a.Total); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Host, b.Host)
+	})
+	return sorted
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+func formatDuration(d time.Duration) string {
+	switch {
+	case d >= time.Minute:
+		return d.Round(time.Second).String()
+	case d >= time.Second:
+		return d.Round(10 * time.Millisecond).String()
+	default:
+		return d.Round(time.Millisecond).String()
+	}
+}
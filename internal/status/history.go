@@ -0,0 +1,221 @@
+package status
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/quickemu-project/quickget_configs/internal/data"
+)
+
+const (
+	// The number of runs kept in the history file. Older runs are dropped as new ones are appended
+	maxHistoryRuns = 180
+	// The number of runs shown in each OS's history strip and in the run time trend
+	historyStripRuns = 30
+)
+
+// The results of one run, stored as a line of the history file
+type RunRecord struct {
+	Start time.Time `json:"start"`
+	// The run time in seconds
+	Seconds int64               `json:"seconds"`
+	OS      map[string]OSRecord `json:"os"`
+}
+
+// The result of one OS in a run. Fields are kept short, since the history file holds many runs of every OS
+type OSRecord struct {
+	// Whether the OS produced any configs
+	OK bool `json:"ok"`
+	// The kind of failure which caused the OS to fail, or the most frequent kind of release failure if it didn't
+	Kind     data.FailureKind `json:"kind,omitempty"`
+	Failures int              `json:"failures,omitempty"`
+	Configs  int              `json:"configs,omitempty"`
+	// The time taken by the OS's config function and validation, in milliseconds
+	Millis int64 `json:"ms"`
+}
+
+// An OS's result in a past run, for its history strip
+type historyEntry struct {
+	Start time.Time
+	// False if the OS wasn't part of the run
+	Present bool
+	OSRecord
+}
+
+// One bar of the run time trend
+type trendBar struct {
+	X, Y, Height int
+	Title        string
+}
+
+const (
+	trendBarWidth = 8
+	trendHeight   = 60
+)
+
+// Reads the history file, returning no runs if it doesn't exist yet
+func loadHistory(path string) ([]RunRecord, error) {
+	contents, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var runs []RunRecord
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	scanner.Buffer(nil, len(contents)+1)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var run RunRecord
+		if err := json.Unmarshal(scanner.Bytes(), &run); err != nil {
+			return nil, fmt.Errorf("Invalid run on line %d of %s: %w", line, path, err)
+		}
+		runs = append(runs, run)
+	}
+	return runs, scanner.Err()
+}
+
+// Writes the history file with one run per line
+func saveHistory(path string, runs []RunRecord) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, run := range runs {
+		if err := enc.Encode(run); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
+// Summarises the current run as a history record
+func (s *Status) record() RunRecord {
+	run := RunRecord{
+		Start:   s.StartTime.UTC().Truncate(time.Second),
+		Seconds: int64(s.EndTime.Sub(s.StartTime).Round(time.Second).Seconds()),
+		OS:      make(map[string]OSRecord, len(s.Data)),
+	}
+	for _, os := range s.Data {
+		record := OSRecord{
+			OK:       os.Err == nil,
+			Failures: os.Failures.Sum(),
+			Millis:   os.Timing.Total().Milliseconds(),
+		}
+		for _, release := range os.Releases {
+			if release.Err == nil {
+				record.Configs++
+			}
+		}
+		if os.Err != nil {
+			record.Kind, _ = data.Classify(os.Err)
+		} else if kinds := os.Failures.Kinds(); len(kinds) > 0 {
+			record.Kind = slices.MaxFunc(kinds, func(a, b data.FailureKind) int {
+				return os.Failures[a] - os.Failures[b]
+			})
+		}
+		run.OS[os.Name] = record
+	}
+	return run
+}
+
+// Appends the current run to the history file, and fills in the history shown on the status page
+func (s *Status) updateHistory() error {
+	runs, err := loadHistory(s.HistoryFile)
+	if err != nil {
+		return err
+	}
+	for i := range s.Data {
+		os := &s.Data[i]
+		os.New = true
+		for _, run := range slices.Backward(runs) {
+			if record, present := run.OS[os.Name]; present {
+				os.New, os.WasFailing = false, !record.OK
+				break
+			}
+		}
+	}
+	runs = append(runs, s.record())
+	runs = runs[max(len(runs)-maxHistoryRuns, 0):]
+	if err := saveHistory(s.HistoryFile, runs); err != nil {
+		return err
+	}
+
+	recent := runs[max(len(runs)-historyStripRuns, 0):]
+	for i := range s.Data {
+		os := &s.Data[i]
+		os.History = make([]historyEntry, len(recent))
+		for j, run := range recent {
+			record, present := run.OS[os.Name]
+			os.History[j] = historyEntry{Start: run.Start, Present: present, OSRecord: record}
+		}
+		if os.Err == nil {
+			continue
+		}
+		// The start of the current streak of failures, across the whole history
+		for j := len(runs) - 1; j >= 0; j-- {
+			record, present := runs[j].OS[os.Name]
+			if present && record.OK {
+				break
+			}
+			if present {
+				os.FailingSince = runs[j].Start
+			}
+		}
+	}
+	s.RunTimes = trendBars(recent)
+	return nil
+}
+
+func trendBars(runs []RunRecord) []trendBar {
+	var longest int64 = 1
+	for _, run := range runs {
+		longest = max(longest, run.Seconds)
+	}
+	bars := make([]trendBar, len(runs))
+	for i, run := range runs {
+		height := max(int(run.Seconds*trendHeight/longest), 1)
+		bars[i] = trendBar{
+			X:      i * trendBarWidth,
+			Y:      trendHeight - height,
+			Height: height,
+			Title:  fmt.Sprintf("%s: %s", run.Start.Format(time.DateOnly), time.Duration(run.Seconds)*time.Second),
+		}
+	}
+	return bars
+}
+
+// The classes of the entry's cell in a history strip
+func (e historyEntry) class() string {
+	switch {
+	case !e.Present:
+		return "cell cell-absent"
+	case !e.OK:
+		return "cell cell-failed"
+	case e.Failures > 0:
+		return "cell cell-partial"
+	default:
+		return "cell cell-ok"
+	}
+}
+
+func (e historyEntry) title() string {
+	date := e.Start.Format(time.DateOnly)
+	switch {
+	case !e.Present:
+		return date + ": not run"
+	case !e.OK:
+		return fmt.Sprintf("%s: failed (%s)", date, e.Kind)
+	case e.Failures > 0:
+		return fmt.Sprintf("%s: %d configs, %d failures (mostly %s)", date, e.Configs, e.Failures, e.Kind)
+	default:
+		return fmt.Sprintf("%s: %d configs", date, e.Configs)
+	}
+}
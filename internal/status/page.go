@@ -0,0 +1,142 @@
+package status
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	qgdata "github.com/quickemu-project/quickget_configs/pkg/quickgetdata"
+)
+
+// Characters which are replaced when building element IDs, so links to them don't need escaping
+var anchorUnsafe = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// The element ID of the OS's section
+func (s osStatus) anchor() string {
+	return "os-" + anchorUnsafe.ReplaceAllString(s.Name, "-")
+}
+
+// The element ID of a release within the OS's section
+func (s osStatus) releaseAnchor(release ReleaseStatus) string {
+	parts := []string{s.anchor(), release.Release}
+	if release.Edition != "" {
+		parts = append(parts, release.Edition)
+	}
+	parts = append(parts, string(archOrDefault(release.Arch)))
+	return anchorUnsafe.ReplaceAllString(strings.Join(parts, "-"), "-")
+}
+
+// "ok" if every release succeeded, "partial" if some failed, or "failed" if the OS produced no configs
+func (s osStatus) state() string {
+	switch {
+	case s.Err != nil:
+		return "failed"
+	case s.Failures.Sum() > 0:
+		return "partial"
+	default:
+		return "ok"
+	}
+}
+
+func (s osStatus) configs() []ReleaseStatus {
+	var configs []ReleaseStatus
+	for _, release := range s.Releases {
+		if release.Err == nil {
+			configs = append(configs, release)
+		}
+	}
+	return configs
+}
+
+// The number of configs whose sources all have checksums, as a fraction of all configs
+func (s osStatus) checksumCoverage() string {
+	configs := s.configs()
+	var covered int
+	for _, config := range configs {
+		if config.hasChecksums() {
+			covered++
+		}
+	}
+	return strconv.Itoa(covered) + "/" + strconv.Itoa(len(configs))
+}
+
+func (s osStatus) arches() string {
+	var arches []string
+	for _, release := range s.configs() {
+		arches = append(arches, string(archOrDefault(release.Arch)))
+	}
+	return uniqueWords(arches)
+}
+
+func (s osStatus) guests() string {
+	var guests []string
+	for _, release := range s.configs() {
+		guests = append(guests, string(guestOrDefault(release.GuestOS)))
+	}
+	return uniqueWords(guests)
+}
+
+// The text matched by the search filter
+func (s osStatus) searchText() string {
+	return strings.ToLower(s.Name + " " + s.PrettyName)
+}
+
+func (r ReleaseStatus) hasChecksums() bool {
+	var sources []qgdata.Source
+	for _, source := range r.Sources {
+		sources = append(sources, source.Source)
+	}
+	for _, disk := range r.DiskImages {
+		sources = append(sources, disk.Source)
+	}
+	for _, source := range sources {
+		switch {
+		case source.Web != nil:
+			if source.Web.Checksum == "" {
+				return false
+			}
+		case source.Kernel != nil:
+			if source.Kernel.Kernel.Checksum == "" {
+				return false
+			}
+			for _, initrd := range source.Kernel.Initrd {
+				if initrd.Checksum == "" {
+					return false
+				}
+			}
+		}
+	}
+	return len(sources) > 0
+}
+
+// Every architecture with configs, for the architecture filter
+func (s *Status) arches() []string {
+	var arches []string
+	for _, os := range s.Data {
+		arches = append(arches, strings.Fields(os.arches())...)
+	}
+	return strings.Fields(uniqueWords(arches))
+}
+
+// Every guest OS with configs, for the guest OS filter
+func (s *Status) guests() []string {
+	var guests []string
+	for _, os := range s.Data {
+		guests = append(guests, strings.Fields(os.guests())...)
+	}
+	return strings.Fields(uniqueWords(guests))
+}
+
+func guestOrDefault(guest qgdata.GuestOS) qgdata.GuestOS {
+	if guest == "" {
+		return qgdata.Linux
+	}
+	return guest
+}
+
+// Sorts and deduplicates words, joining them with spaces for use in a data attribute
+func uniqueWords(words []string) string {
+	slices.Sort(words)
+	return strings.Join(slices.Compact(words), " ")
+}
@@ -0,0 +1,47 @@
+package status
+
+import (
+	"log"
+	"strings"
+
+	"github.com/quickemu-project/quickget_configs/internal/data"
+)
+
+// Records the regressions since the previous run, shown as warnings alongside each OS
+func (s *Status) SetRegressions(regressions []data.Regression) {
+	s.Lock()
+	defer s.Unlock()
+	s.Regressions = regressions
+	for i := range s.Data {
+		s.Data[i].Regressions = nil
+	}
+	for _, regression := range regressions {
+		for i := range s.Data {
+			if s.Data[i].Name == regression.OS {
+				s.Data[i].Regressions = append(s.Data[i].Regressions, regression)
+			}
+		}
+		log.Printf("Regression (%s): %s %s: %s", regression.Kind, regression.OS, regressionTarget(regression), regression.Message)
+	}
+}
+
+// Describes the configs affected by a regression, such as "24.04 (desktop) - x86_64" or "aarch64"
+func regressionTarget(r data.Regression) string {
+	var parts []string
+	if r.Release != "" {
+		parts = append(parts, r.Release)
+	}
+	if r.Edition != "" {
+		parts = append(parts, "("+r.Edition+")")
+	}
+	if r.Arch != "" {
+		if len(parts) > 0 {
+			parts = append(parts, "-")
+		}
+		parts = append(parts, string(r.Arch))
+	}
+	if len(parts) == 0 {
+		return "all releases"
+	}
+	return strings.Join(parts, " ")
+}
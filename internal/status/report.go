@@ -0,0 +1,339 @@
+package status
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/quickemu-project/quickget_configs/internal/data"
+	qgdata "github.com/quickemu-project/quickget_configs/pkg/quickgetdata"
+)
+
+// The version of the status.json structure. Fields may be added without changing it, but it's incremented if any are removed or change meaning
+const reportVersion = 1
+
+// The structure of status.json, a machine-readable summary of a run
+type Report struct {
+	Version int          `json:"version"`
+	Start   time.Time    `json:"start"`
+	End     time.Time    `json:"end"`
+	Seconds float64      `json:"seconds"`
+	Counts  ReportCounts `json:"counts"`
+	// Failures of each kind, in total, per OS and per host
+	Failures FailureReport `json:"failures"`
+	// Every OS, sorted by name
+	OS []OSReport `json:"os"`
+	// The names of every OS, sorted by the time taken then the requests made, the most expensive first
+	ByCost []string `json:"by_cost"`
+	// Requests which couldn't be attributed to an OS
+	Unattributed OSUsageReport `json:"unattributed"`
+	// Every host requested, the one with the most time spent waiting for responses first
+	Hosts []HostLatencyReport `json:"hosts"`
+	// Differences from the previous run's configs, sorted by OS. Empty if no previous data was given
+	Regressions []RegressionReport `json:"regressions"`
+}
+
+type ReportCounts struct {
+	OS       int `json:"os"`
+	FailedOS int `json:"failed_os"`
+	Configs  int `json:"configs"`
+	// Releases which were expected but couldn't be created or failed validation
+	ReleaseFailures  int `json:"release_failures"`
+	ChecksumFailures int `json:"checksum_failures"`
+	Regressions      int `json:"regressions"`
+}
+
+type OSReport struct {
+	Name       string `json:"name"`
+	PrettyName string `json:"pretty_name"`
+	// Whether the OS produced any configs
+	OK bool `json:"ok"`
+	// Why the OS produced no configs, present only if OK is false
+	Error *ErrorReport `json:"error,omitempty"`
+	// The time taken in total, and by creating and validating configs
+	Seconds           float64       `json:"seconds"`
+	ScrapeSeconds     float64       `json:"scrape_seconds"`
+	ValidationSeconds float64       `json:"validation_seconds"`
+	Usage             OSUsageReport `json:"usage"`
+	Configs           int           `json:"configs"`
+	// The start of the current streak of failures, present only if the OS failed and history is enabled
+	FailingSince *time.Time `json:"failing_since,omitempty"`
+	// Whether the OS has no earlier results in the history, or failed in the latest earlier run which included it.
+	// Both are false if history isn't enabled
+	New              bool            `json:"new,omitempty"`
+	WasFailing       bool            `json:"was_failing,omitempty"`
+	Failures         []FailureDetail `json:"failures"`
+	ChecksumFailures []FailureDetail `json:"checksum_failures"`
+}
+
+type OSUsageReport struct {
+	Scrape     UsageReport `json:"scrape"`
+	Validation UsageReport `json:"validation"`
+}
+
+type UsageReport struct {
+	Requests int `json:"requests"`
+	// Attempts repeated after an error, not included in Requests
+	Retries int   `json:"retries"`
+	Bytes   int64 `json:"bytes"`
+}
+
+type HostLatencyReport struct {
+	Host         string  `json:"host"`
+	Requests     int     `json:"requests"`
+	TotalSeconds float64 `json:"total_seconds"`
+	MeanSeconds  float64 `json:"mean_seconds"`
+	MaxSeconds   float64 `json:"max_seconds"`
+}
+
+func usageReport(usage data.NetworkUsage) UsageReport {
+	return UsageReport{Requests: usage.Requests, Retries: usage.Retries, Bytes: usage.Bytes}
+}
+
+func osUsageReport(usage data.OSUsage) OSUsageReport {
+	return OSUsageReport{Scrape: usageReport(usage.Scrape), Validation: usageReport(usage.Validation)}
+}
+
+type RegressionReport struct {
+	OS   string              `json:"os"`
+	Kind data.RegressionKind `json:"kind"`
+	// The config affected, with fields omitted if the regression affects more than one
+	Release string      `json:"release,omitempty"`
+	Edition string      `json:"edition,omitempty"`
+	Arch    qgdata.Arch `json:"arch,omitempty"`
+	Message string      `json:"message"`
+}
+
+type FailureDetail struct {
+	Release string      `json:"release"`
+	Edition string      `json:"edition,omitempty"`
+	Arch    qgdata.Arch `json:"arch"`
+	Error   ErrorReport `json:"error"`
+}
+
+type ErrorReport struct {
+	Kind data.FailureKind `json:"kind"`
+	// The host involved, if known
+	Host    string `json:"host,omitempty"`
+	Message string `json:"message"`
+}
+
+func errorReport(err error) ErrorReport {
+	kind, host := data.Classify(err)
+	return ErrorReport{Kind: kind, Host: host, Message: err.Error()}
+}
+
+func failureDetails(failures []data.Failure) []FailureDetail {
+	details := make([]FailureDetail, 0, len(failures))
+	for _, failure := range failures {
+		details = append(details, FailureDetail{
+			Release: failure.Release,
+			Edition: failure.Edition,
+			Arch:    archOrDefault(failure.Arch),
+			Error:   errorReport(failure.Error),
+		})
+	}
+	return details
+}
+
+// Returns the summary of the run written to status.json. Fields set by Finalize are only filled in once it has been called
+func (s *Status) Report() Report {
+	s.Lock()
+	defer s.Unlock()
+	return s.report()
+}
+
+func (s *Status) report() Report {
+	report := Report{
+		Version:      reportVersion,
+		Start:        s.StartTime,
+		End:          s.EndTime,
+		Seconds:      s.EndTime.Sub(s.StartTime).Seconds(),
+		Failures:     s.Failures,
+		OS:           make([]OSReport, 0, len(s.Data)),
+		ByCost:       make([]string, 0, len(s.Data)),
+		Unattributed: osUsageReport(s.Unattributed),
+		Hosts:        make([]HostLatencyReport, 0, len(s.Latency)),
+		Regressions:  make([]RegressionReport, 0, len(s.Regressions)),
+	}
+	for _, os := range s.Data {
+		osReport := OSReport{
+			Name:              os.Name,
+			PrettyName:        os.PrettyName,
+			OK:                os.Err == nil,
+			Seconds:           os.Timing.Total().Seconds(),
+			ScrapeSeconds:     os.Timing.Scrape.Seconds(),
+			ValidationSeconds: os.Timing.Validation.Seconds(),
+			Usage:             osUsageReport(os.Usage),
+			New:               os.New,
+			WasFailing:        os.WasFailing,
+			ChecksumFailures:  failureDetails(os.CsFailures),
+		}
+		if os.Err != nil {
+			errReport := errorReport(os.Err)
+			osReport.Error = &errReport
+			report.Counts.FailedOS++
+		}
+		if !os.FailingSince.IsZero() {
+			osReport.FailingSince = &os.FailingSince
+		}
+		var failures []data.Failure
+		for _, release := range os.Releases {
+			if release.Err == nil {
+				osReport.Configs++
+			} else {
+				failures = append(failures, data.Failure{Release: release.Release, Edition: release.Edition, Arch: release.Arch, Error: release.Err})
+			}
+		}
+		osReport.Failures = failureDetails(failures)
+
+		report.Counts.OS++
+		report.Counts.Configs += osReport.Configs
+		report.Counts.ReleaseFailures += len(osReport.Failures)
+		report.Counts.ChecksumFailures += len(osReport.ChecksumFailures)
+		report.OS = append(report.OS, osReport)
+	}
+	for _, regression := range s.Regressions {
+		report.Regressions = append(report.Regressions, RegressionReport(regression))
+	}
+	report.Counts.Regressions = len(report.Regressions)
+	for _, os := range s.byCost() {
+		report.ByCost = append(report.ByCost, os.Name)
+	}
+	for _, host := range s.latencyByCost() {
+		report.Hosts = append(report.Hosts, HostLatencyReport{
+			Host:         host.Host,
+			Requests:     host.Requests,
+			TotalSeconds: host.Total.Seconds(),
+			MeanSeconds:  host.Mean().Seconds(),
+			MaxSeconds:   host.Max.Seconds(),
+		})
+	}
+	return report
+}
+
+// The JUnit XML format understood by CI systems. Each OS is a test suite, with a test case for each config or failed release
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	Cases     []junitTestCase `xml:"testcase"`
+	// Checksum failures and regressions, which don't fail a config
+	SystemErr string `xml:"system-err,omitempty"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure"`
+}
+
+type junitFailure struct {
+	Type    string `xml:"type,attr"`
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func junitSeconds(seconds float64) string {
+	return fmt.Sprintf("%.3f", seconds)
+}
+
+func junitFailureOf(err ErrorReport) *junitFailure {
+	return &junitFailure{Type: string(err.Kind), Message: err.Message, Text: err.Message}
+}
+
+func releaseName(release, edition string, arch qgdata.Arch) string {
+	name := release
+	if edition != "" {
+		name += " (" + edition + ")"
+	}
+	return name + " - " + string(archOrDefault(arch))
+}
+
+func (r Report) junit(s *Status) junitTestSuites {
+	suites := junitTestSuites{
+		Name:   "quickget configs",
+		Time:   junitSeconds(r.Seconds),
+		Suites: make([]junitTestSuite, 0, len(r.OS)),
+	}
+	for i, osReport := range r.OS {
+		suite := junitTestSuite{
+			Name:      osReport.Name,
+			Time:      junitSeconds(osReport.Seconds),
+			Timestamp: r.Start.Format("2006-01-02T15:04:05"),
+		}
+		if osReport.Error != nil {
+			suite.Cases = append(suite.Cases, junitTestCase{
+				Name:      "configs",
+				ClassName: osReport.Name,
+				Failure:   junitFailureOf(*osReport.Error),
+			})
+		}
+		for _, release := range s.Data[i].Releases {
+			if release.Err == nil {
+				suite.Cases = append(suite.Cases, junitTestCase{
+					Name:      releaseName(release.Release, release.Edition, release.Arch),
+					ClassName: osReport.Name,
+				})
+			}
+		}
+		for _, failure := range osReport.Failures {
+			suite.Cases = append(suite.Cases, junitTestCase{
+				Name:      releaseName(failure.Release, failure.Edition, failure.Arch),
+				ClassName: osReport.Name,
+				Failure:   junitFailureOf(failure.Error),
+			})
+		}
+		var systemErr strings.Builder
+		for _, failure := range osReport.ChecksumFailures {
+			fmt.Fprintf(&systemErr, "%s: checksum failure (%s): %s\n", releaseName(failure.Release, failure.Edition, failure.Arch), failure.Error.Kind, failure.Error.Message)
+		}
+		for _, regression := range s.Data[i].Regressions {
+			fmt.Fprintf(&systemErr, "%s: regression (%s): %s\n", regressionTarget(regression), regression.Kind, regression.Message)
+		}
+		suite.SystemErr = systemErr.String()
+
+		suite.Tests = len(suite.Cases)
+		for _, c := range suite.Cases {
+			if c.Failure != nil {
+				suite.Failures++
+			}
+		}
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Suites = append(suites.Suites, suite)
+	}
+	return suites
+}
+
+// Writes status.json and junit.xml to the status page directory
+func (s *Status) writeReports() error {
+	report := s.report()
+	contents, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(statusPageDir+"/status.json", contents, 0644); err != nil {
+		return err
+	}
+
+	contents, err = xml.MarshalIndent(report.junit(s), "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(statusPageDir+"/junit.xml", append([]byte(xml.Header), contents...), 0644)
+}
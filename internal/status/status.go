@@ -3,6 +3,7 @@ package status
 import (
 	"context"
 	"log"
+	"maps"
 	"os"
 	"slices"
 	"strings"
@@ -18,6 +19,49 @@ type Status struct {
 	StartTime time.Time
 	EndTime   time.Time
 	Data      []osStatus
+	// Hosts which throttled or failed requests during the run
+	Throttling []data.HostThrottling
+	// Requests which couldn't be attributed to an OS
+	Unattributed data.OSUsage
+	// Response times of every host requested during the run
+	Latency []data.HostLatency
+	// Differences from the previous run's configs which suggest an OS is no longer scraped correctly, sorted by OS
+	Regressions []data.Regression
+	// Failures by kind, set when the status is finalized
+	Failures FailureReport
+	// The file which results are appended to, so trends can be shown. History is disabled if empty
+	HistoryFile string
+	// Bars showing the run time of recent runs, set when the status is finalized with a history file
+	RunTimes []trendBar
+}
+
+// The number of failures of each kind
+type FailureCounts map[data.FailureKind]int
+
+// Failures of each kind across the run, for each OS and for each host involved
+type FailureReport struct {
+	Total  FailureCounts            `json:"total"`
+	ByOS   map[string]FailureCounts `json:"by_os"`
+	ByHost map[string]FailureCounts `json:"by_host"`
+}
+
+// Returns the kinds with at least one failure, in report order
+func (c FailureCounts) Kinds() []data.FailureKind {
+	var kinds []data.FailureKind
+	for _, kind := range data.FailureKinds {
+		if c[kind] > 0 {
+			kinds = append(kinds, kind)
+		}
+	}
+	return kinds
+}
+
+func (c FailureCounts) Sum() int {
+	var sum int
+	for _, n := range c {
+		sum += n
+	}
+	return sum
 }
 
 type osStatus struct {
@@ -27,12 +71,44 @@ type osStatus struct {
 	Description string
 	Releases    []ReleaseStatus
 	Err         error
+	Failures    FailureCounts
+	// Failures of each kind involving each host
+	HostFailures map[string]FailureCounts
+	// The time taken to create and validate the OS's configs
+	Timing data.OSTiming
+	// Requests made while creating and validating the OS's configs, set by SetUsage
+	Usage data.OSUsage
+	// Results of recent runs including this one, oldest first
+	History []historyEntry
+	// The start of the first run in the current streak of failures, zero if the OS didn't fail
+	FailingSince time.Time
+	// Whether the OS has no earlier results in the history. Always false without a history file
+	New bool
+	// Whether the OS failed in the latest earlier run which included it. Always false without a history file
+	WasFailing bool
+	// Every checksum failure, including those which couldn't be matched to a config
+	CsFailures []data.Failure
+	// Regressions of the OS's configs since the previous run
+	Regressions []data.Regression
+}
+
+func (s *osStatus) count(err error) {
+	kind, host := data.Classify(err)
+	s.Failures[kind]++
+	if host == "" {
+		return
+	}
+	if s.HostFailures[host] == nil {
+		s.HostFailures[host] = make(FailureCounts)
+	}
+	s.HostFailures[host][kind]++
 }
 
 type ReleaseStatus struct {
 	Release    string
 	Edition    string
 	Arch       qgdata.Arch
+	GuestOS    qgdata.GuestOS
 	Sources    []sourceData
 	DiskImages []qgdata.Disk
 	CsErrs     []error
@@ -53,57 +129,173 @@ func Create(len int) *Status {
 
 func makeOsStatus(data qgdata.OSData) osStatus {
 	return osStatus{
-		Name:        data.Name,
-		PrettyName:  data.PrettyName,
-		Homepage:    data.Homepage,
-		Description: data.Description,
+		Name:         data.Name,
+		PrettyName:   data.PrettyName,
+		Homepage:     data.Homepage,
+		Description:  data.Description,
+		Failures:     make(FailureCounts),
+		HostFailures: make(map[string]FailureCounts),
 	}
 }
 
-func (s *Status) FailedOS(data qgdata.OSData, err error) {
+// Records an OS which produced no configs. Any failures reported by its config function before it failed are included
+func (s *Status) FailedOS(data qgdata.OSData, timing data.OSTiming, err error, failures, csFailures []data.Failure) {
 	log.Println(data.PrettyName, "failed:", err)
 	s.Lock()
 	defer s.Unlock()
 	status := makeOsStatus(data)
 	status.Err = err
+	status.Timing = timing
+	// When releases failed, their failures explain the error in more detail and are counted instead
+	if len(failures) == 0 {
+		status.count(err)
+	}
+	status.addFailures(failures, csFailures)
 	s.Data = append(s.Data, status)
 }
 
-func (s *Status) AddOS(data qgdata.OSData, failures, csFailures []data.Failure) {
+func (s *Status) AddOS(data qgdata.OSData, timing data.OSTiming, failures, csFailures []data.Failure) {
 	s.Lock()
 	defer s.Unlock()
 	status := makeOsStatus(data)
-	for _, failure := range failures {
-		status.Releases = append(status.Releases, ReleaseStatus{
-			Release: failure.Release,
-			Edition: failure.Edition,
-			Arch:    failure.Arch,
-			Err:     failure.Error,
-		})
-		log.Printf("Failure: %s", failure)
-	}
-	for _, failure := range csFailures {
-		log.Printf("(Unimplemented) Checksum Failure: %s", failure)
-	}
+	status.Timing = timing
 	for _, config := range data.Releases {
-		sourceLen := len(config.ISO) + len(config.IMG) + len(config.FixedISO) + len(config.Floppy)
+		sourceLen := len(config.ISO) + len(config.IMG) + len(config.FixedISO) + len(config.Floppy) + len(config.KernelBoot)
 		sources := make([]sourceData, 0, sourceLen)
 		addSources(&sources, "ISO", config.ISO)
 		addSources(&sources, "IMG", config.IMG)
 		addSources(&sources, "Fixed ISO (CD-ROM)", config.FixedISO)
 		addSources(&sources, "Floppy", config.Floppy)
+		addSources(&sources, "Kernel Boot", config.KernelBoot)
 
 		status.Releases = append(status.Releases, ReleaseStatus{
 			Release:    config.Release,
 			Edition:    config.Edition,
 			Arch:       config.Arch,
+			GuestOS:    config.GuestOS,
 			Sources:    sources,
 			DiskImages: config.DiskImages,
 		})
 	}
+	status.addFailures(failures, csFailures)
 	s.Data = append(s.Data, status)
 }
 
+func (s *osStatus) addFailures(failures, csFailures []data.Failure) {
+	for _, failure := range failures {
+		kind, _ := failure.Kind()
+		s.Releases = append(s.Releases, ReleaseStatus{
+			Release: failure.Release,
+			Edition: failure.Edition,
+			Arch:    failure.Arch,
+			Err:     failure.Error,
+		})
+		s.count(failure.Error)
+		log.Printf("Failure (%s): %s", kind, failure)
+	}
+	s.CsFailures = append(s.CsFailures, csFailures...)
+	for _, failure := range csFailures {
+		kind, _ := failure.Kind()
+		// Checksum failures don't prevent a config from being used, so they're shown alongside it
+		i := slices.IndexFunc(s.Releases, func(r ReleaseStatus) bool {
+			return r.Err == nil && r.Release == failure.Release && r.Edition == failure.Edition && archOrDefault(r.Arch) == archOrDefault(failure.Arch)
+		})
+		if i != -1 {
+			s.Releases[i].CsErrs = append(s.Releases[i].CsErrs, failure.Error)
+		}
+		s.count(failure.Error)
+		log.Printf("Checksum failure (%s): %s", kind, failure)
+	}
+}
+
+func archOrDefault(arch qgdata.Arch) qgdata.Arch {
+	if arch == "" {
+		return qgdata.X86_64
+	}
+	return arch
+}
+
+// Returns the hosts in the report, those with the most failures first
+func (r FailureReport) Hosts() []string {
+	hosts := slices.Collect(maps.Keys(r.ByHost))
+	slices.SortFunc(hosts, func(a, b string) int {
+		if cmp := r.ByHost[b].Sum() - r.ByHost[a].Sum(); cmp != 0 {
+			return cmp
+		}
+		return strings.Compare(a, b)
+	})
+	return hosts
+}
+
+func failureKind(err error) string {
+	kind, _ := data.Classify(err)
+	return string(kind)
+}
+
+// Totals the failures of every OS
+func (s *Status) failureReport() FailureReport {
+	report := FailureReport{
+		Total:  make(FailureCounts),
+		ByOS:   make(map[string]FailureCounts),
+		ByHost: make(map[string]FailureCounts),
+	}
+	for _, os := range s.Data {
+		if len(os.Failures) == 0 {
+			continue
+		}
+		report.ByOS[os.Name] = os.Failures
+		for kind, n := range os.Failures {
+			report.Total[kind] += n
+		}
+		for host, counts := range os.HostFailures {
+			if report.ByHost[host] == nil {
+				report.ByHost[host] = make(FailureCounts)
+			}
+			for kind, n := range counts {
+				report.ByHost[host][kind] += n
+			}
+		}
+	}
+	return report
+}
+
+func (s *Status) SetThrottling(throttling []data.HostThrottling) {
+	s.Lock()
+	defer s.Unlock()
+	s.Throttling = throttling
+	for _, host := range throttling {
+		log.Printf("Throttling: %s was throttled %d times over %d requests (%d failed), lowest concurrency %d, waited %s",
+			host.Host, host.Throttled, host.Requests, host.Failures, host.MinConcurrency, host.Waited.Round(time.Second))
+	}
+}
+
+// Records the requests made on behalf of each OS, keyed by name with unattributed requests under "", and the response times of each host
+func (s *Status) SetUsage(usage map[string]data.OSUsage, latency []data.HostLatency) {
+	s.Lock()
+	defer s.Unlock()
+	for i := range s.Data {
+		s.Data[i].Usage = usage[s.Data[i].Name]
+	}
+	s.Unattributed = usage[""]
+	s.Latency = latency
+
+	for _, os := range s.byCost()[:min(len(s.Data), costlyOSLogged)] {
+		total := os.Usage.Total()
+		log.Printf("Cost: %s took %s (%s scraping, %s validating) over %d requests (%d retries), %s received",
+			os.Name, os.Timing.Total().Round(time.Millisecond), os.Timing.Scrape.Round(time.Millisecond), os.Timing.Validation.Round(time.Millisecond),
+			total.Requests, total.Retries, formatBytes(total.Bytes))
+	}
+	if s.Unattributed.Total().Requests > 0 {
+		log.Printf("Cost: %d requests couldn't be attributed to an OS", s.Unattributed.Total().Requests)
+	}
+}
+
+func (s *Status) SetHistoryFile(path string) {
+	s.Lock()
+	defer s.Unlock()
+	s.HistoryFile = path
+}
+
 func addSources(data *[]sourceData, sourceType string, sources []qgdata.Source) {
 	for _, source := range sources {
 		*data = append(*data, sourceData{
@@ -117,11 +309,18 @@ const statusPageDir = "statuspage"
 
 func (s *Status) Finalize() error {
 	s.Lock()
+	defer s.Unlock()
 	s.EndTime = time.Now()
 
 	slices.SortFunc(s.Data, func(a, b osStatus) int {
 		return strings.Compare(a.Name, b.Name)
 	})
+	s.Failures = s.failureReport()
+	if s.HistoryFile != "" {
+		if err := s.updateHistory(); err != nil {
+			log.Printf("Could not update run history: %s", err)
+		}
+	}
 
 	page := statusTempl(s)
 	if err := os.RemoveAll(statusPageDir); err != nil {
@@ -137,6 +336,9 @@ func (s *Status) Finalize() error {
 	if err := page.Render(context.Background(), file); err != nil {
 		return err
 	}
+	if err := s.writeBadges(); err != nil {
+		return err
+	}
 
-	return nil
+	return s.writeReports()
 }
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"cmp"
+	"context"
 	"fmt"
 	"iter"
 	"regexp"
@@ -48,8 +49,8 @@ func WaitForConfigs(ch chan Config, wg *sync.WaitGroup) []Config {
 	return configs
 }
 
-func GetSortedReleasesFunc(url string, pattern any, num int, cmp func(a, b string) int) ([]string, error) {
-	page, err := web.CapturePage(url)
+func GetSortedReleasesFunc(ctx context.Context, url string, pattern any, num int, cmp func(a, b string) int) ([]string, error) {
+	page, err := web.CapturePage(ctx, url)
 	if err != nil {
 		return nil, err
 	}
@@ -73,12 +74,12 @@ func GetSortedReleasesFunc(url string, pattern any, num int, cmp func(a, b strin
 	return releases, nil
 }
 
-func GetSortedReleases(url string, pattern any, num int) ([]string, error) {
-	return GetSortedReleasesFunc(url, pattern, num, strings.Compare)
+func GetSortedReleases(ctx context.Context, url string, pattern any, num int) ([]string, error) {
+	return GetSortedReleasesFunc(ctx, url, pattern, num, strings.Compare)
 }
 
-func GetReverseReleases(url string, pattern any, num int) (iter.Seq[string], int, error) {
-	page, err := web.CapturePage(url)
+func GetReverseReleases(ctx context.Context, url string, pattern any, num int) (iter.Seq[string], int, error) {
+	page, err := web.CapturePage(ctx, url)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -103,8 +104,8 @@ func GetReverseReleases(url string, pattern any, num int) (iter.Seq[string], int
 	}, len(matches), nil
 }
 
-func GetBasicReleases(url string, pattern any, num int) (iter.Seq[string], int, error) {
-	page, err := web.CapturePage(url)
+func GetBasicReleases(ctx context.Context, url string, pattern any, num int) (iter.Seq[string], int, error) {
+	page, err := web.CapturePage(ctx, url)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -165,5 +166,7 @@ type OS struct {
 	PrettyName     string
 	Description    string
 	Homepage       string
-	ConfigFunction func(errs, csErrs chan<- Failure) ([]Config, error)
+	ConfigFunction func(ctx context.Context, errs, csErrs chan<- Failure) ([]Config, error)
+	// Hardware recommendations shared by every config of this OS, taking priority over the guest OS defaults
+	Hardware *quickgetdata.Hardware
 }
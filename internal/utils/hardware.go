@@ -0,0 +1,178 @@
+package utils
+
+import qgdata "github.com/quickemu-project/quickget_configs/pkg/quickgetdata"
+
+// Baseline virtual hardware for each guest OS. Distros can override these with OS.Hardware, and providers with Config.Hardware
+var hardwareDefaults = map[qgdata.GuestOS]qgdata.Hardware{
+	qgdata.Linux: {
+		MinRAM:         1 * qgdata.GiB,
+		RecommendedRAM: 4 * qgdata.GiB,
+		CPUs:           2,
+		DiskSize:       16 * qgdata.GiB,
+		Firmware:       qgdata.AnyFirmware,
+		NetworkDevice:  qgdata.VirtioNet,
+		DiskBus:        qgdata.VirtioBlk,
+	},
+	qgdata.LinuxOld: {
+		MinRAM:         512 * qgdata.MiB,
+		RecommendedRAM: 2 * qgdata.GiB,
+		CPUs:           2,
+		DiskSize:       16 * qgdata.GiB,
+		Firmware:       qgdata.BIOS,
+		NetworkDevice:  qgdata.E1000,
+		DiskBus:        qgdata.SATA,
+	},
+	qgdata.Windows: {
+		MinRAM:         2 * qgdata.GiB,
+		RecommendedRAM: 8 * qgdata.GiB,
+		CPUs:           4,
+		DiskSize:       64 * qgdata.GiB,
+		Firmware:       qgdata.EFI,
+		NetworkDevice:  qgdata.E1000e,
+		DiskBus:        qgdata.SATA,
+	},
+	qgdata.WindowsServer: {
+		MinRAM:         2 * qgdata.GiB,
+		RecommendedRAM: 8 * qgdata.GiB,
+		CPUs:           4,
+		DiskSize:       64 * qgdata.GiB,
+		Firmware:       qgdata.EFI,
+		NetworkDevice:  qgdata.E1000e,
+		DiskBus:        qgdata.SATA,
+	},
+	qgdata.MacOS: {
+		MinRAM:         4 * qgdata.GiB,
+		RecommendedRAM: 8 * qgdata.GiB,
+		CPUs:           4,
+		DiskSize:       128 * qgdata.GiB,
+		Firmware:       qgdata.EFI,
+		NetworkDevice:  qgdata.Vmxnet3,
+		DiskBus:        qgdata.SATA,
+	},
+	qgdata.FreeBSD: {
+		MinRAM:         1 * qgdata.GiB,
+		RecommendedRAM: 2 * qgdata.GiB,
+		CPUs:           2,
+		DiskSize:       16 * qgdata.GiB,
+		Firmware:       qgdata.AnyFirmware,
+		NetworkDevice:  qgdata.VirtioNet,
+		DiskBus:        qgdata.VirtioBlk,
+	},
+	qgdata.GhostBSD: {
+		MinRAM:         2 * qgdata.GiB,
+		RecommendedRAM: 4 * qgdata.GiB,
+		CPUs:           2,
+		DiskSize:       32 * qgdata.GiB,
+		Firmware:       qgdata.AnyFirmware,
+		NetworkDevice:  qgdata.VirtioNet,
+		DiskBus:        qgdata.VirtioBlk,
+	},
+	qgdata.GenericBSD: {
+		MinRAM:         512 * qgdata.MiB,
+		RecommendedRAM: 2 * qgdata.GiB,
+		CPUs:           2,
+		DiskSize:       16 * qgdata.GiB,
+		Firmware:       qgdata.AnyFirmware,
+		NetworkDevice:  qgdata.VirtioNet,
+		DiskBus:        qgdata.VirtioBlk,
+	},
+	qgdata.FreeDOS: {
+		MinRAM:         16 * qgdata.MiB,
+		RecommendedRAM: 64 * qgdata.MiB,
+		CPUs:           1,
+		DiskSize:       2 * qgdata.GiB,
+		Firmware:       qgdata.BIOS,
+		NetworkDevice:  qgdata.PCNet,
+		DiskBus:        qgdata.IDE,
+	},
+	qgdata.Haiku: {
+		MinRAM:         512 * qgdata.MiB,
+		RecommendedRAM: 2 * qgdata.GiB,
+		CPUs:           2,
+		DiskSize:       16 * qgdata.GiB,
+		Firmware:       qgdata.AnyFirmware,
+		NetworkDevice:  qgdata.E1000,
+		DiskBus:        qgdata.SATA,
+	},
+	qgdata.Solaris: {
+		MinRAM:         2 * qgdata.GiB,
+		RecommendedRAM: 4 * qgdata.GiB,
+		CPUs:           2,
+		DiskSize:       32 * qgdata.GiB,
+		Firmware:       qgdata.BIOS,
+		NetworkDevice:  qgdata.E1000,
+		DiskBus:        qgdata.SATA,
+	},
+	qgdata.KolibriOS: {
+		MinRAM:         16 * qgdata.MiB,
+		RecommendedRAM: 256 * qgdata.MiB,
+		CPUs:           1,
+		DiskSize:       1 * qgdata.GiB,
+		Firmware:       qgdata.BIOS,
+		NetworkDevice:  qgdata.RTL8139,
+		DiskBus:        qgdata.IDE,
+	},
+	qgdata.ReactOS: {
+		MinRAM:         256 * qgdata.MiB,
+		RecommendedRAM: 2 * qgdata.GiB,
+		CPUs:           1,
+		DiskSize:       16 * qgdata.GiB,
+		Firmware:       qgdata.BIOS,
+		NetworkDevice:  qgdata.E1000,
+		DiskBus:        qgdata.IDE,
+	},
+	qgdata.Batocera: {
+		MinRAM:         2 * qgdata.GiB,
+		RecommendedRAM: 4 * qgdata.GiB,
+		CPUs:           2,
+		DiskSize:       32 * qgdata.GiB,
+		Firmware:       qgdata.AnyFirmware,
+		NetworkDevice:  qgdata.VirtioNet,
+		DiskBus:        qgdata.VirtioBlk,
+	},
+}
+
+// Returns the hardware for a config, with any unset fields filled in from the distro and guest OS defaults (in that order)
+func resolveHardware(config Config, distro *qgdata.Hardware) *qgdata.Hardware {
+	var hw qgdata.Hardware
+	if config.Hardware != nil {
+		hw = *config.Hardware
+	}
+	if distro != nil {
+		fillHardware(&hw, *distro)
+	}
+	fillHardware(&hw, hardwareDefaults[config.GuestOS])
+	// A provider raising the minimum shouldn't leave the recommendation below it
+	hw.RecommendedRAM = max(hw.RecommendedRAM, hw.MinRAM)
+	return &hw
+}
+
+func fillHardware(hw *qgdata.Hardware, defaults qgdata.Hardware) {
+	if hw.MinRAM == 0 {
+		hw.MinRAM = defaults.MinRAM
+	}
+	if hw.RecommendedRAM == 0 {
+		hw.RecommendedRAM = defaults.RecommendedRAM
+	}
+	if hw.CPUs == 0 {
+		hw.CPUs = defaults.CPUs
+	}
+	if hw.DiskSize == 0 {
+		hw.DiskSize = defaults.DiskSize
+	}
+	if hw.Firmware == "" {
+		hw.Firmware = defaults.Firmware
+	}
+	if hw.SecureBoot == nil {
+		hw.SecureBoot = defaults.SecureBoot
+	}
+	if hw.TPM == "" {
+		hw.TPM = defaults.TPM
+	}
+	if hw.NetworkDevice == "" {
+		hw.NetworkDevice = defaults.NetworkDevice
+	}
+	if hw.DiskBus == "" {
+		hw.DiskBus = defaults.DiskBus
+	}
+}
@@ -1,14 +1,16 @@
 package utils
 
 import (
+	"context"
 	"errors"
-	"fmt"
 	"log"
 	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/go-version"
+	"github.com/quickemu-project/quickget_configs/internal/data"
 	"github.com/quickemu-project/quickget_configs/internal/status"
 	"github.com/quickemu-project/quickget_configs/internal/web"
 	qgdata "github.com/quickemu-project/quickget_configs/pkg/quickgetdata"
@@ -26,7 +28,7 @@ func SpawnDistros(distros ...OS) ([]OSData, *status.Status) {
 			Homepage:    distro.Homepage,
 		}
 		if distro.ConfigFunction == nil {
-			status.FailedOS(os, errors.New("Config function is nil"))
+			status.FailedOS(os, data.OSTiming{}, errors.New("Config function is nil"), nil, nil)
 			continue
 		}
 
@@ -34,47 +36,60 @@ func SpawnDistros(distros ...OS) ([]OSData, *status.Status) {
 		csErrs := make(chan Failure)
 
 		var failureSlice, csFailureSlice []Failure
-		go func() {
+		// Done once both channels are closed and drained
+		var collected sync.WaitGroup
+		collected.Go(func() {
 			for failure := range failures {
 				failureSlice = append(failureSlice, failure)
 			}
-		}()
-		go func() {
+		})
+		collected.Go(func() {
 			for csFailure := range csErrs {
 				csFailureSlice = append(csFailureSlice, csFailure)
 			}
-		}()
+		})
 
 		wg.Go(func() {
+			ctx := web.WithAccount(context.Background(), distro.Name, web.Scraping)
+			var timing data.OSTiming
+			start := time.Now()
 			defer func() {
 				if r := recover(); r != nil {
+					if timing.Scrape == 0 {
+						timing.Scrape = time.Since(start)
+					}
 					close(failures)
 					close(csErrs)
-					status.FailedOS(os, fmt.Errorf("panic: %s", r))
+					collected.Wait()
+					status.FailedOS(os, timing, data.Errorf(data.Panic, "panic: %s", r), failureSlice, csFailureSlice)
 				}
 			}()
 
-			configs, err := distro.ConfigFunction(failures, csErrs)
+			configs, err := distro.ConfigFunction(ctx, failures, csErrs)
+			timing.Scrape = time.Since(start)
 
 			if err != nil {
-				status.FailedOS(os, err)
+				close(failures)
+				close(csErrs)
+				collected.Wait()
+				status.FailedOS(os, timing, err, failureSlice, csFailureSlice)
 				return
 			}
-			configs = web.RemoveInvalidConfigs(configs, failures, csErrs)
+			start = time.Now()
+			configs = web.RemoveInvalidConfigs(web.WithAccount(context.Background(), distro.Name, web.Validating), configs, failures, csErrs)
+			timing.Validation = time.Since(start)
 
 			close(failures)
 			close(csErrs)
+			collected.Wait()
 
 			if len(configs) == 0 {
-				for _, failure := range failureSlice {
-					log.Printf("Failure: %s", failure)
-				}
-				status.FailedOS(os, errors.New("no valid configs found"))
+				status.FailedOS(os, timing, errors.New("no valid configs found"), failureSlice, csFailureSlice)
 				return
 			}
 
-			os.Releases = fixConfigs(configs)
-			status.AddOS(os, failureSlice, csFailureSlice)
+			os.Releases = fixConfigs(configs, distro.Hardware)
+			status.AddOS(os, timing, failureSlice, csFailureSlice)
 			if len(configs) > 0 {
 				ch <- os
 			}
@@ -97,7 +112,7 @@ func SpawnDistros(distros ...OS) ([]OSData, *status.Status) {
 	return data, status
 }
 
-func fixConfigs(configs []Config) []Config {
+func fixConfigs(configs []Config, hardware *qgdata.Hardware) []Config {
 	// We want to sort releases in descending order; editions are typically strings and should be sorted lexicographically
 	slices.SortFunc(configs, func(a, b Config) int {
 		if aSemver, err := version.NewVersion(a.Release); err == nil {
@@ -118,16 +133,23 @@ func fixConfigs(configs []Config) []Config {
 		if config.GuestOS == "" {
 			config.GuestOS = qgdata.Linux
 		}
-		if config.Arch == "" || config.Arch == "amd64" {
+		if config.Arch == "" {
 			config.Arch = qgdata.X86_64
-		} else if config.Arch == "arm64" {
-			config.Arch = qgdata.Aarch64
+		} else if arch, valid := qgdata.NewArch(string(config.Arch)); valid {
+			config.Arch = arch
 		}
 		if config.Release == "" {
 			config.Release = "latest"
 		}
+		config.Hardware = resolveHardware(*config, hardware)
+		config.TPM = config.Hardware.TPM != ""
+		config.RAM = config.Hardware.RecommendedRAM
 	}
 	return slices.DeleteFunc(configs, func(c Config) bool {
-		return c.Arch != qgdata.X86_64 && c.Arch != qgdata.Aarch64 && c.Arch != qgdata.Riscv64
+		if _, valid := qgdata.NewArch(string(c.Arch)); !valid {
+			log.Printf("Discarding %s %s config with unknown architecture %q", c.Release, c.Edition, c.Arch)
+			return true
+		}
+		return false
 	})
 }
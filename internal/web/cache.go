@@ -0,0 +1,265 @@
+package web
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// Bodies larger than this, such as disk images, are streamed to the caller without being cached
+	maxCachedBodySize = 16 * 1024 * 1024
+	// Entries which haven't been used for this long are removed by PruneCache
+	cacheExpiry = 7 * 24 * time.Hour
+)
+
+// How long a cached response may be used without revalidating it. Hosts not listed are always revalidated
+var cacheMaxAge = map[string]time.Duration{
+	"api.launchpad.net":  6 * time.Hour,
+	"fedorapeople.org":   12 * time.Hour,
+	"fedoraproject.org":  time.Hour,
+	"archlinux.org":      time.Hour,
+	"cloud.debian.org":   time.Hour,
+	"cdimage.debian.org": time.Hour,
+}
+
+// The on-disk response cache. Caching is disabled while this is nil
+var cache *httpCache
+
+type httpCache struct {
+	dir string
+}
+
+type cacheEntry struct {
+	URL          string      `json:"url"`
+	FinalURL     string      `json:"final_url"`
+	ETag         string      `json:"etag,omitempty"`
+	LastModified string      `json:"last_modified,omitempty"`
+	Header       http.Header `json:"header"`
+	// The last time the server confirmed this entry was current
+	ValidatedAt time.Time `json:"validated_at"`
+
+	key  string
+	body []byte
+}
+
+// Enables caching of GET responses in the given directory, which is created if it doesn't exist.
+// The directory can be persisted between runs to avoid downloading pages which haven't changed
+func EnableCache(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	cache = &httpCache{dir: dir}
+	return nil
+}
+
+// Removes cache entries which haven't been validated recently
+func PruneCache() error {
+	if cache == nil {
+		return nil
+	}
+	return filepath.WalkDir(cache.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ".json") {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if time.Since(info.ModTime()) > cacheExpiry {
+			base := strings.TrimSuffix(path, ".json")
+			return errors.Join(os.Remove(path), os.Remove(base+".body"))
+		}
+		return nil
+	})
+}
+
+func cacheKey(u *url.URL, headers http.Header) string {
+	hash := sha256.New()
+	io.WriteString(hash, u.String())
+	keys := make([]string, 0, len(headers))
+	for k := range headers {
+		// Credentials can change between runs without changing the response
+		if k != "Authorization" {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		io.WriteString(hash, "\n"+k+": "+strings.Join(headers[k], ","))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func (c *httpCache) path(key string) string {
+	// Split entries over subdirectories to keep directory sizes reasonable
+	return filepath.Join(c.dir, key[:2], key)
+}
+
+func (c *httpCache) load(key string) *cacheEntry {
+	path := c.path(key)
+	meta, err := os.ReadFile(path + ".json")
+	if err != nil {
+		return nil
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(meta, &entry); err != nil {
+		return nil
+	}
+	body, err := os.ReadFile(path + ".body")
+	if err != nil {
+		return nil
+	}
+	entry.key = key
+	entry.body = body
+	return &entry
+}
+
+func (c *httpCache) save(entry *cacheEntry) error {
+	path := c.path(entry.key)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	meta, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	// Write the body first, so an entry's metadata never refers to a partially written body
+	if err := writeFileAtomic(path+".body", entry.body); err != nil {
+		return err
+	}
+	return writeFileAtomic(path+".json", meta)
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
+
+// Reports whether the entry can be used without asking the server
+func (e *cacheEntry) fresh(host string) bool {
+	maxAge, ok := cacheMaxAge[host]
+	return ok && time.Since(e.ValidatedAt) < maxAge
+}
+
+func (e *cacheEntry) addConditionalHeaders(headers http.Header) {
+	if e.ETag != "" {
+		headers.Set("If-None-Match", e.ETag)
+	}
+	if e.LastModified != "" {
+		headers.Set("If-Modified-Since", e.LastModified)
+	}
+}
+
+// Builds a response equivalent to the one the entry was created from
+func (e *cacheEntry) response() (*http.Response, error) {
+	finalUrl, err := url.Parse(e.FinalURL)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       &http.Request{Method: http.MethodGet, URL: finalUrl},
+	}, nil
+}
+
+// Headers of a 304 response which describe its empty body rather than the stored one, so they aren't copied to the entry
+var bodyHeaders = []string{"Content-Length", "Content-Encoding", "Content-Range", "Content-Type", "Transfer-Encoding"}
+
+// Marks an entry as confirmed current by the server (HTTP 304) and returns its response.
+// The 304's headers replace the stored ones, since some such as rate limits change even when the body doesn't
+func (c *httpCache) revalidated(entry *cacheEntry, header http.Header) (*http.Response, error) {
+	entry.Header = entry.Header.Clone()
+	if entry.Header == nil {
+		entry.Header = make(http.Header)
+	}
+	for name, values := range header {
+		if !slices.Contains(bodyHeaders, name) {
+			entry.Header[name] = values
+		}
+	}
+	if etag := header.Get("ETag"); etag != "" {
+		entry.ETag = etag
+	}
+	if lastModified := header.Get("Last-Modified"); lastModified != "" {
+		entry.LastModified = lastModified
+	}
+	entry.ValidatedAt = time.Now()
+	if err := c.save(entry); err != nil {
+		log.Printf("Warning: could not update cache entry for %s: %s", entry.URL, err)
+	}
+	return entry.response()
+}
+
+// Stores a successful response, returning a response the caller can read as normal.
+// Responses which can't be revalidated or are too large are passed through untouched
+func (c *httpCache) store(key string, u *url.URL, resp *http.Response) (*http.Response, error) {
+	etag, lastModified := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+	_, hasMaxAge := cacheMaxAge[u.Hostname()]
+	if etag == "" && lastModified == "" && !hasMaxAge {
+		return resp, nil
+	}
+	if resp.ContentLength > maxCachedBodySize {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxCachedBodySize+1))
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if len(body) > maxCachedBodySize {
+		// Hand back what has been read so far, followed by the remainder of the stream
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return resp, nil
+	}
+	resp.Body.Close()
+
+	entry := &cacheEntry{
+		URL:          u.String(),
+		FinalURL:     resp.Request.URL.String(),
+		ETag:         etag,
+		LastModified: lastModified,
+		Header:       resp.Header,
+		ValidatedAt:  time.Now(),
+		key:          key,
+		body:         body,
+	}
+	if err := c.save(entry); err != nil {
+		log.Printf("Warning: could not cache %s: %s", entry.URL, err)
+	}
+	return entry.response()
+}
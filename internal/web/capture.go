@@ -10,19 +10,63 @@ import (
 	"net/url"
 
 	"github.com/hashicorp/go-retryablehttp"
+	"github.com/quickemu-project/quickget_configs/internal/data"
 	"golang.org/x/sync/semaphore"
 )
 
 var (
-	client     = retryablehttp.NewClient()
-	permits    = semaphore.NewWeighted(150)
-	urlPermits = map[string]*semaphore.Weighted{
-		"sourceforge.net": semaphore.NewWeighted(5),
-		"zrn.co":          semaphore.NewWeighted(3),
-	}
+	client = newClient()
+	// Never repeats a request, for those which aren't safe to send twice. A server error doesn't mean the request wasn't acted on
+	noRetryClient = newNoRetryClient()
+	permits       = semaphore.NewWeighted(150)
 )
 
-func GetResponse[T string | *url.URL](input T, headers http.Header) (*http.Response, error) {
+func newClient() *retryablehttp.Client {
+	client := retryablehttp.NewClient()
+	client.CheckRetry = checkRetry
+	client.Backoff = backoff
+	// Return the last response once retries are exhausted, so callers can decide how to treat throttling
+	client.ErrorHandler = retryablehttp.PassthroughErrorHandler
+	client.RequestLogHook = func(_ retryablehttp.Logger, req *http.Request, attempt int) {
+		if attempt > 0 {
+			recordRetry(req.Context())
+		}
+	}
+	return client
+}
+
+func newNoRetryClient() *retryablehttp.Client {
+	client := newClient()
+	client.RetryMax = 0
+	return client
+}
+
+// Sets the maximum number of requests in flight across all hosts. Must be called before any requests are made
+func SetGlobalLimit(n int) {
+	permits = semaphore.NewWeighted(int64(n))
+}
+
+// Returned when a server responds with a non-success status. The headers are kept for callers that handle rate limiting themselves
+type StatusError struct {
+	URL        string
+	StatusCode int
+	Status     string
+	Header     http.Header
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("Failed to make response to page %s: %s", e.URL, e.Status)
+}
+
+func (e *StatusError) FailureKind() (data.FailureKind, string) {
+	var host string
+	if u, err := url.Parse(e.URL); err == nil {
+		host = u.Hostname()
+	}
+	return data.HTTPStatus, host
+}
+
+func GetResponse[T string | *url.URL](ctx context.Context, input T, headers http.Header) (*http.Response, error) {
 	var u *url.URL
 	switch v := any(input).(type) {
 	case string:
@@ -34,37 +78,55 @@ func GetResponse[T string | *url.URL](input T, headers http.Header) (*http.Respo
 	case *url.URL:
 		u = v
 	}
+	return getMemoizedResponse(ctx, u, headers)
+}
 
-	if sem, exists := urlPermits[u.Hostname()]; exists {
-		if err := sem.Acquire(context.Background(), 1); err != nil {
-			return nil, err
-		}
-		defer sem.Release(1)
+func fetchResponse(ctx context.Context, u *url.URL, headers http.Header) (*http.Response, error) {
+	if u.Scheme == "ftp" {
+		return retrieveFTP(ctx, u)
 	}
-	if err := permits.Acquire(context.Background(), 1); err != nil {
-		return nil, err
+	var key string
+	var entry *cacheEntry
+	if cache != nil {
+		key = cacheKey(u, headers)
+		entry = cache.load(key)
+		if entry != nil && entry.fresh(u.Hostname()) {
+			return entry.response()
+		}
 	}
-	defer permits.Release(1)
 
-	req, err := retryablehttp.NewRequest("GET", u.String(), nil)
+	req, err := retryablehttp.NewRequestWithContext(ctx, "GET", u.String(), nil)
 	if err != nil {
 		return nil, err
 	}
-	req.Header = headers
-	resp, err := client.Do(req)
+	req.Header = headers.Clone()
+	if req.Header == nil {
+		req.Header = make(http.Header)
+	}
+	if entry != nil {
+		entry.addConditionalHeaders(req.Header)
+	}
+	resp, err := doLimited(client, req)
 	if err != nil {
 		return nil, err
 	}
+	if entry != nil && resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+		return cache.revalidated(entry, resp.Header)
+	}
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		defer resp.Body.Close()
-		return nil, fmt.Errorf("Failed to make response to page %s: %s", input, resp.Status)
+		return nil, &StatusError{URL: u.String(), StatusCode: resp.StatusCode, Status: resp.Status, Header: resp.Header}
 	}
 
+	if cache != nil {
+		return cache.store(key, u, resp)
+	}
 	return resp, nil
 }
 
-func FinalRedirectUrl[T string | *url.URL](input T) (string, error) {
-	resp, err := GetResponse(input, nil)
+func FinalRedirectUrl[T string | *url.URL](ctx context.Context, input T) (string, error) {
+	resp, err := GetResponse(ctx, input, nil)
 	if err != nil {
 		return "", err
 	}
@@ -73,8 +135,8 @@ func FinalRedirectUrl[T string | *url.URL](input T) (string, error) {
 	return resp.Request.URL.String(), nil
 }
 
-func capturePageToBytes[T string | *url.URL](input T, headers http.Header) ([]byte, error) {
-	resp, err := GetResponse(input, headers)
+func capturePageToBytes[T string | *url.URL](ctx context.Context, input T, headers http.Header) ([]byte, error) {
+	resp, err := GetResponse(ctx, input, headers)
 	if err != nil {
 		return nil, err
 	}
@@ -87,29 +149,32 @@ func capturePageToBytes[T string | *url.URL](input T, headers http.Header) ([]by
 	return body, nil
 }
 
-func CapturePage[T string | *url.URL](input T) (string, error) {
-	body, err := capturePageToBytes(input, nil)
+func CapturePage[T string | *url.URL](ctx context.Context, input T) (string, error) {
+	body, err := capturePageToBytes(ctx, input, nil)
 	if err != nil {
 		return "", err
 	}
 	return string(body), nil
 }
-func capturePageToUnmarshal[T string | *url.URL](url T, data any, unmarshal func([]byte, any) error, headers http.Header) error {
-	page, err := capturePageToBytes(url, headers)
+func capturePageToUnmarshal[T string | *url.URL](ctx context.Context, url T, v any, unmarshal func([]byte, any) error, headers http.Header) error {
+	page, err := capturePageToBytes(ctx, url, headers)
 	if err != nil {
 		return err
 	}
-	return unmarshal(page, data)
+	if err := unmarshal(page, v); err != nil {
+		return data.Errorf(data.Parse, "Failed to decode %v: %w", url, err)
+	}
+	return nil
 }
 
-func CapturePageToJson[T string | *url.URL](url T, data any) error {
-	return capturePageToUnmarshal(url, data, json.Unmarshal, nil)
+func CapturePageToJson[T string | *url.URL](ctx context.Context, url T, data any) error {
+	return capturePageToUnmarshal(ctx, url, data, json.Unmarshal, nil)
 }
 
-func CapturePageAcceptingJson[T string | *url.URL](url T, data any) error {
-	return capturePageToUnmarshal(url, data, json.Unmarshal, http.Header{"Accept": []string{"application/json"}})
+func CapturePageAcceptingJson[T string | *url.URL](ctx context.Context, url T, data any) error {
+	return capturePageToUnmarshal(ctx, url, data, json.Unmarshal, http.Header{"Accept": []string{"application/json"}})
 }
 
-func CapturePageToXml[T string | *url.URL](url T, data any) error {
-	return capturePageToUnmarshal(url, data, xml.Unmarshal, nil)
+func CapturePageToXml[T string | *url.URL](ctx context.Context, url T, data any) error {
+	return capturePageToUnmarshal(ctx, url, data, xml.Unmarshal, nil)
 }
@@ -0,0 +1,443 @@
+package web
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+const ftpTimeout = time.Minute
+
+// An entry of an FTP directory listing
+type FTPEntry struct {
+	Name    string
+	IsDir   bool
+	Size    int64
+	ModTime time.Time
+}
+
+var (
+	ftpPasvRe = regexp.MustCompile(`(\d+),(\d+),(\d+),(\d+),(\d+),(\d+)`)
+	ftpEpsvRe = regexp.MustCompile(`\(\|\|\|(\d+)\|\)`)
+)
+
+type ftpConn struct {
+	*textproto.Conn
+	raw  net.Conn
+	host string
+}
+
+// Lists a directory on an FTP server, using MLSD where supported and falling back to parsing LIST output
+func ReadFTPDir(ctx context.Context, u *url.URL) ([]FTPEntry, error) {
+	var entries []FTPEntry
+	err := withFTPConn(ctx, u, func(c *ftpConn) error {
+		var err error
+		entries, err = c.mlsd(ctx, u.Path)
+		var protoErr *textproto.Error
+		if errors.As(err, &protoErr) && protoErr.Code >= 500 {
+			entries, err = c.list(ctx, u.Path)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list FTP directory %s: %w", u, err)
+	}
+	return entries, nil
+}
+
+// Checks that a file exists on an FTP server, returning its size and modification time where the server reports them
+func probeFTP(ctx context.Context, u *url.URL) (*urlInfo, error) {
+	info := &urlInfo{FinalURL: u, Size: -1}
+	err := withFTPConn(ctx, u, func(c *ftpConn) error {
+		// SIZE is only defined for binary transfers
+		if _, _, err := c.cmd(2, "TYPE I"); err != nil {
+			return err
+		}
+		_, msg, err := c.cmd(2, "SIZE %s", u.Path)
+		if err != nil {
+			return err
+		}
+		if size, err := strconv.ParseInt(strings.TrimSpace(msg), 10, 64); err == nil {
+			info.Size = size
+		}
+		if _, msg, err := c.cmd(2, "MDTM %s", u.Path); err == nil {
+			info.LastModified, _ = parseFTPTime(strings.TrimSpace(msg))
+		}
+		return nil
+	})
+	var protoErr *textproto.Error
+	if errors.As(err, &protoErr) && protoErr.Code == 550 {
+		info.StatusCode, info.Status = 404, "550 "+protoErr.Msg
+		return info, nil
+	} else if err != nil {
+		return nil, err
+	}
+	info.StatusCode, info.Status = 200, "200 OK"
+	return info, nil
+}
+
+// Downloads a file from an FTP server. The connection is held, within the host's request limits, until the body is closed
+func retrieveFTP(ctx context.Context, u *url.URL) (*http.Response, error) {
+	release, err := acquireHost(ctx, u.Hostname())
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	c, err := dialFTP(ctx, u)
+	if err != nil {
+		recordRequest(ctx, u.Hostname(), time.Since(start))
+		release(0)
+		return nil, err
+	}
+	fail := func(err error) (*http.Response, error) {
+		recordRequest(ctx, u.Hostname(), time.Since(start))
+		c.Close()
+		release(ftpStatus(err))
+		var protoErr *textproto.Error
+		if errors.As(err, &protoErr) && protoErr.Code == 550 {
+			return nil, &StatusError{URL: u.String(), StatusCode: http.StatusNotFound, Status: "550 " + protoErr.Msg}
+		}
+		return nil, err
+	}
+	if _, _, err := c.cmd(2, "TYPE I"); err != nil {
+		return fail(err)
+	}
+	data, err := c.passive(ctx)
+	if err != nil {
+		return fail(err)
+	}
+	if _, _, err := c.cmd(1, "RETR %s", u.Path); err != nil {
+		data.Close()
+		return fail(err)
+	}
+	acct := recordRequest(ctx, u.Hostname(), time.Since(start))
+	// Large downloads need longer than the control connection's deadline, so they're only limited by the context
+	deadline, _ := ctx.Deadline()
+	data.SetDeadline(deadline)
+	c.raw.SetDeadline(deadline)
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        make(http.Header),
+		Body:          &countingBody{ReadCloser: &ftpBody{Conn: data, control: c, release: release}, account: acct},
+		ContentLength: -1,
+		Request:       &http.Request{Method: http.MethodGet, URL: u},
+	}, nil
+}
+
+type ftpBody struct {
+	net.Conn
+	control *ftpConn
+	release func(status int)
+	once    sync.Once
+}
+
+func (b *ftpBody) Close() error {
+	var err error
+	b.once.Do(func() {
+		b.Conn.Close()
+		b.control.raw.SetDeadline(time.Now().Add(ftpTimeout))
+		// The transfer result is irrelevant if the caller stopped reading early
+		b.control.ReadResponse(2)
+		b.control.Cmd("QUIT")
+		err = b.control.Close()
+		b.release(http.StatusOK)
+	})
+	return err
+}
+
+// Connects and logs in to the server of the URL within the host's request limits, closing the connection once f returns
+func withFTPConn(ctx context.Context, u *url.URL, f func(c *ftpConn) error) error {
+	release, err := acquireHost(ctx, u.Hostname())
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	c, err := dialFTP(ctx, u)
+	if err != nil {
+		recordRequest(ctx, u.Hostname(), time.Since(start))
+		release(0)
+		return err
+	}
+	err = f(c)
+	recordRequest(ctx, u.Hostname(), time.Since(start))
+	c.Cmd("QUIT")
+	c.Close()
+	release(ftpStatus(err))
+	return err
+}
+
+// Maps the outcome of an FTP session to the HTTP status the host limiter understands
+func ftpStatus(err error) int {
+	var protoErr *textproto.Error
+	switch {
+	case err == nil:
+		return 200
+	case errors.As(err, &protoErr) && protoErr.Code == 421:
+		// The server has too many connections
+		return 429
+	case errors.As(err, &protoErr):
+		// The server is responding, so it shouldn't be backed off from
+		return 200
+	default:
+		return 0
+	}
+}
+
+// Returns the deadline of an FTP exchange starting now, which is ftpTimeout away unless the context ends sooner
+func ftpDeadline(ctx context.Context) time.Time {
+	deadline := time.Now().Add(ftpTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		return d
+	}
+	return deadline
+}
+
+func dialFTP(ctx context.Context, u *url.URL) (*ftpConn, error) {
+	port := u.Port()
+	if port == "" {
+		port = "21"
+	}
+	raw, err := (&net.Dialer{Timeout: ftpTimeout}).DialContext(ctx, "tcp", net.JoinHostPort(u.Hostname(), port))
+	if err != nil {
+		return nil, err
+	}
+	raw.SetDeadline(ftpDeadline(ctx))
+	c := &ftpConn{Conn: textproto.NewConn(raw), raw: raw, host: u.Hostname()}
+	if _, _, err := c.ReadResponse(2); err != nil {
+		c.Close()
+		return nil, err
+	}
+
+	user, pass := "anonymous", "anonymous@"
+	if u.User != nil {
+		user = u.User.Username()
+		if p, ok := u.User.Password(); ok {
+			pass = p
+		}
+	}
+	code, _, err := c.cmd(0, "USER %s", user)
+	if err == nil && code == 331 {
+		_, _, err = c.cmd(2, "PASS %s", pass)
+	} else if err == nil && code/100 != 2 {
+		err = fmt.Errorf("FTP login rejected with code %d", code)
+	}
+	if err != nil {
+		c.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// Sends a command and reads the response, which must have the expected code (or class of code, e.g. 2 for 2xx).
+// An expected code of 0 accepts any response
+func (c *ftpConn) cmd(expect int, format string, args ...any) (int, string, error) {
+	if _, err := c.Cmd(format, args...); err != nil {
+		return 0, "", err
+	}
+	if expect == 0 {
+		code, msg, err := c.ReadResponse(0)
+		var protoErr *textproto.Error
+		if errors.As(err, &protoErr) {
+			return protoErr.Code, protoErr.Msg, nil
+		}
+		return code, msg, err
+	}
+	return c.ReadResponse(expect)
+}
+
+// Opens a data connection, preferring extended passive mode
+func (c *ftpConn) passive(ctx context.Context) (net.Conn, error) {
+	var port string
+	if _, msg, err := c.cmd(2, "EPSV"); err == nil {
+		if match := ftpEpsvRe.FindStringSubmatch(msg); match != nil {
+			port = match[1]
+		}
+	}
+	if port == "" {
+		_, msg, err := c.cmd(2, "PASV")
+		if err != nil {
+			return nil, err
+		}
+		match := ftpPasvRe.FindStringSubmatch(msg)
+		if match == nil {
+			return nil, fmt.Errorf("Invalid PASV response %q", msg)
+		}
+		high, _ := strconv.Atoi(match[5])
+		low, _ := strconv.Atoi(match[6])
+		port = strconv.Itoa(high<<8 | low)
+	}
+	// The address in a PASV response is ignored, since servers behind NAT often report a private one
+	conn, err := (&net.Dialer{Timeout: ftpTimeout}).DialContext(ctx, "tcp", net.JoinHostPort(c.host, port))
+	if err != nil {
+		return nil, err
+	}
+	conn.SetDeadline(ftpDeadline(ctx))
+	return conn, nil
+}
+
+// Runs a command which sends its output over a data connection, returning each line of the output
+func (c *ftpConn) dataLines(ctx context.Context, format string, args ...any) ([]string, error) {
+	data, err := c.passive(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer data.Close()
+	if _, _, err := c.cmd(1, format, args...); err != nil {
+		return nil, err
+	}
+	var lines []string
+	scanner := bufio.NewScanner(data)
+	for scanner.Scan() {
+		if line := strings.TrimRight(scanner.Text(), "\r"); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if _, _, err := c.ReadResponse(2); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+func (c *ftpConn) mlsd(ctx context.Context, dir string) ([]FTPEntry, error) {
+	lines, err := c.dataLines(ctx, "MLSD %s", ftpDir(dir))
+	if err != nil {
+		return nil, err
+	}
+	return parseMLSD(lines), nil
+}
+
+func parseMLSD(lines []string) []FTPEntry {
+	entries := make([]FTPEntry, 0, len(lines))
+	for _, line := range lines {
+		// type=file;size=1234;modify=20240101120000; name
+		facts, name, found := strings.Cut(line, " ")
+		if !found {
+			continue
+		}
+		entry := FTPEntry{Name: name, Size: -1}
+		var entryType string
+		for fact := range strings.SplitSeq(facts, ";") {
+			key, value, _ := strings.Cut(fact, "=")
+			switch strings.ToLower(key) {
+			case "type":
+				entryType = strings.ToLower(value)
+			case "size":
+				if size, err := strconv.ParseInt(value, 10, 64); err == nil {
+					entry.Size = size
+				}
+			case "modify":
+				entry.ModTime, _ = parseFTPTime(value)
+			}
+		}
+		switch entryType {
+		case "dir":
+			entry.IsDir = true
+		case "file":
+		default:
+			// The current and parent directories, and links whose targets are unknown
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// Parses the output of LIST, which is unstandardised but almost always in the format of `ls -l`
+func (c *ftpConn) list(ctx context.Context, dir string) ([]FTPEntry, error) {
+	lines, err := c.dataLines(ctx, "LIST %s", ftpDir(dir))
+	if err != nil {
+		return nil, err
+	}
+	return parseList(lines, time.Now().UTC()), nil
+}
+
+func parseList(lines []string, now time.Time) []FTPEntry {
+	entries := make([]FTPEntry, 0, len(lines))
+	for _, line := range lines {
+		// drwxr-xr-x 2 ftp ftp 4096 Jan 01 12:00 name
+		fields := strings.Fields(line)
+		// Symlinks (l) are skipped like in MLSD listings, since LIST doesn't say whether they point to files or directories
+		if len(fields) < 9 || (line[0] != '-' && line[0] != 'd') {
+			continue
+		}
+		name, ok := listName(line)
+		if !ok || name == "." || name == ".." {
+			continue
+		}
+		size, err := strconv.ParseInt(fields[4], 10, 64)
+		if err != nil {
+			size = -1
+		}
+		entries = append(entries, FTPEntry{
+			Name:    name,
+			IsDir:   line[0] == 'd',
+			Size:    size,
+			ModTime: parseListTime(fields[5], fields[6], fields[7], now),
+		})
+	}
+	return entries
+}
+
+// Returns the name from a LIST line, which may contain spaces, by taking the remainder of the line after the first 8 fields
+func listName(line string) (string, bool) {
+	rest := line
+	for range 8 {
+		rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+		i := strings.IndexFunc(rest, unicode.IsSpace)
+		if i == -1 {
+			return "", false
+		}
+		rest = rest[i:]
+	}
+	return strings.TrimLeftFunc(rest, unicode.IsSpace), true
+}
+
+func ftpDir(dir string) string {
+	if dir == "" {
+		return "/"
+	}
+	return path.Clean(dir)
+}
+
+// Parses the timestamps used by MLSD and MDTM, which are always in UTC
+func parseFTPTime(value string) (time.Time, error) {
+	value, _, _ = strings.Cut(value, ".")
+	return time.Parse("20060102150405", value)
+}
+
+// Parses a LIST timestamp, which has a time of day instead of a year for recent files
+func parseListTime(month, day, yearOrTime string, now time.Time) time.Time {
+	if strings.Contains(yearOrTime, ":") {
+		t, err := time.Parse("Jan 2 2006 15:04", fmt.Sprintf("%s %s %d %s", month, day, now.Year(), yearOrTime))
+		if err != nil {
+			return time.Time{}
+		}
+		// Recent files without a year can be from late last year
+		if t.After(now.Add(24 * time.Hour)) {
+			t = t.AddDate(-1, 0, 0)
+		}
+		return t
+	}
+	t, _ := time.Parse("Jan 2 2006", fmt.Sprintf("%s %s %s", month, day, yearOrTime))
+	return t
+}
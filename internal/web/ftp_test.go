@@ -0,0 +1,122 @@
+package web
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseList(t *testing.T) {
+	now := time.Date(2024, time.March, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		lines []string
+		want  []FTPEntry
+	}{
+		{
+			name:  "unix file",
+			lines: []string{"-rw-r--r-- 1 ftp ftp 1234 Jan 05 2023 image.iso"},
+			want: []FTPEntry{
+				{Name: "image.iso", Size: 1234, ModTime: time.Date(2023, time.January, 5, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+		{
+			name:  "unix directory with a recent time",
+			lines: []string{"drwxr-xr-x 2 ftp ftp 4096 Mar 01 08:30 releases"},
+			want: []FTPEntry{
+				{Name: "releases", IsDir: true, Size: 4096, ModTime: time.Date(2024, time.March, 1, 8, 30, 0, 0, time.UTC)},
+			},
+		},
+		{
+			name:  "recent time from last year",
+			lines: []string{"-rw-r--r-- 1 ftp ftp 1 Dec 24 18:00 old"},
+			want: []FTPEntry{
+				{Name: "old", Size: 1, ModTime: time.Date(2023, time.December, 24, 18, 0, 0, 0, time.UTC)},
+			},
+		},
+		{
+			name:  "multiple spaces and tabs",
+			lines: []string{"-rw-r--r--    1 ftp\tftp      98765 Feb  9  2022 name with  spaces.iso"},
+			want: []FTPEntry{
+				{Name: "name with  spaces.iso", Size: 98765, ModTime: time.Date(2022, time.February, 9, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+		{
+			name: "symlinks and special entries are skipped",
+			lines: []string{
+				"lrwxrwxrwx 1 ftp ftp 7 Jan 01 2020 latest -> 1.0",
+				"drwxr-xr-x 2 ftp ftp 4096 Jan 01 2020 .",
+				"drwxr-xr-x 2 ftp ftp 4096 Jan 01 2020 ..",
+				"total 12",
+				"-rw-r--r-- 1 ftp ftp 12 Jan 01",
+			},
+			want: []FTPEntry{},
+		},
+		{
+			name:  "unparseable size is unknown",
+			lines: []string{"-rw-r--r-- 1 ftp ftp ? Jan 01 2020 file"},
+			want: []FTPEntry{
+				{Name: "file", Size: -1, ModTime: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := parseList(test.lines, now)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("parseList() = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseMLSD(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  []FTPEntry
+	}{
+		{
+			name:  "file",
+			lines: []string{"type=file;size=1234;modify=20240101120000; image.iso"},
+			want: []FTPEntry{
+				{Name: "image.iso", Size: 1234, ModTime: time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)},
+			},
+		},
+		{
+			name:  "directory with fractional seconds and mixed case facts",
+			lines: []string{"Type=dir;Modify=20230615083000.123;UNIX.mode=0755; releases"},
+			want: []FTPEntry{
+				{Name: "releases", IsDir: true, Size: -1, ModTime: time.Date(2023, time.June, 15, 8, 30, 0, 0, time.UTC)},
+			},
+		},
+		{
+			name:  "name with spaces",
+			lines: []string{"type=file;size=1; name with spaces.iso"},
+			want:  []FTPEntry{{Name: "name with spaces.iso", Size: 1}},
+		},
+		{
+			name:  "unparseable size is unknown",
+			lines: []string{"type=file;size=unknown;modify=bad; file"},
+			want:  []FTPEntry{{Name: "file", Size: -1}},
+		},
+		{
+			name: "special entries and links are skipped",
+			lines: []string{
+				"type=cdir;modify=20240101120000; .",
+				"type=pdir;modify=20240101120000; ..",
+				"type=OS.unix=symlink;modify=20240101120000; latest",
+				"malformed",
+			},
+			want: []FTPEntry{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := parseMLSD(test.lines)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("parseMLSD() = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
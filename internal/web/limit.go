@@ -0,0 +1,279 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/quickemu-project/quickget_configs/internal/data"
+)
+
+// Limits on the requests made to a single host
+type HostLimit struct {
+	// The maximum number of requests in flight at once
+	Concurrency int
+	// The maximum sustained request rate. Zero disables rate limiting
+	RequestsPerSecond float64
+}
+
+const (
+	// The longest a host may pause all requests to it with Retry-After
+	maxRetryAfter = 2 * time.Minute
+	// The number of consecutive successful requests (relative to the current concurrency) required to raise the concurrency again
+	recoveryFactor = 2
+)
+
+var (
+	defaultHostLimit = HostLimit{Concurrency: 150}
+	hostLimits       = map[string]HostLimit{
+		"sourceforge.net": {Concurrency: 5, RequestsPerSecond: 5},
+		"zrn.co":          {Concurrency: 3},
+		"api.github.com":  {Concurrency: 4},
+	}
+
+	limitersLock sync.Mutex
+	limiters     = make(map[string]*hostLimiter)
+)
+
+// Sets the limits for a host. Must be called before any requests are made to it
+func SetHostLimit(host string, limit HostLimit) {
+	limitersLock.Lock()
+	defer limitersLock.Unlock()
+	hostLimits[host] = limit
+}
+
+// Parses host limits in the form "host=concurrency[:requests per second],..." and applies them
+func ParseHostLimits(input string) error {
+	for entry := range strings.SplitSeq(input, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		host, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			return fmt.Errorf("Invalid host limit %q: expected host=concurrency[:rps]", entry)
+		}
+		concurrencyStr, rpsStr, hasRps := strings.Cut(value, ":")
+		concurrency, err := strconv.Atoi(concurrencyStr)
+		if err != nil || concurrency < 1 {
+			return fmt.Errorf("Invalid concurrency in host limit %q", entry)
+		}
+		limit := HostLimit{Concurrency: concurrency}
+		if hasRps {
+			limit.RequestsPerSecond, err = strconv.ParseFloat(rpsStr, 64)
+			if err != nil || limit.RequestsPerSecond < 0 {
+				return fmt.Errorf("Invalid request rate in host limit %q", entry)
+			}
+		}
+		SetHostLimit(host, limit)
+	}
+	return nil
+}
+
+type hostLimiter struct {
+	sync.Mutex
+	cond *sync.Cond
+
+	max      int
+	interval time.Duration
+
+	// The current concurrency, lowered when the host fails and gradually raised again as it recovers
+	limit     int
+	active    int
+	successes int
+	// The earliest time the next request may start, based on the request rate
+	next time.Time
+	// Requests are held until this time after the host sends Retry-After
+	pausedUntil time.Time
+
+	stats data.HostThrottling
+}
+
+func getLimiter(host string) *hostLimiter {
+	limitersLock.Lock()
+	defer limitersLock.Unlock()
+	if l, ok := limiters[host]; ok {
+		return l
+	}
+	limit, ok := hostLimits[host]
+	if !ok {
+		limit = defaultHostLimit
+	}
+	l := &hostLimiter{
+		max:   max(limit.Concurrency, 1),
+		limit: max(limit.Concurrency, 1),
+	}
+	l.stats = data.HostThrottling{Host: host, MinConcurrency: l.limit}
+	if limit.RequestsPerSecond > 0 {
+		l.interval = time.Duration(float64(time.Second) / limit.RequestsPerSecond)
+	}
+	l.cond = sync.NewCond(l)
+	limiters[host] = l
+	return l
+}
+
+// Waits for permission to make a request to the host, and for a global permit.
+// The returned function must be called with the final status code (or 0 on a network error) once the request completes
+func acquireHost(ctx context.Context, host string) (func(status int), error) {
+	l := getLimiter(host)
+	l.Lock()
+	if l.active >= l.limit {
+		// Wakes the loop below when the context ends, since waiting on the condition can't observe it
+		stop := context.AfterFunc(ctx, func() {
+			l.Lock()
+			defer l.Unlock()
+			l.cond.Broadcast()
+		})
+		defer stop()
+	}
+	for l.active >= l.limit {
+		if err := ctx.Err(); err != nil {
+			l.Unlock()
+			return nil, err
+		}
+		l.cond.Wait()
+	}
+	l.active++
+	now := time.Now()
+	start := now
+	if l.next.After(start) {
+		start = l.next
+	}
+	if l.pausedUntil.After(start) {
+		start = l.pausedUntil
+	}
+	if l.interval > 0 {
+		l.next = start.Add(l.interval)
+	}
+	wait := start.Sub(now)
+	l.stats.Waited += wait
+	l.Unlock()
+
+	if wait > 0 {
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			l.abandon()
+			return nil, ctx.Err()
+		}
+	}
+
+	if err := permits.Acquire(ctx, 1); err != nil {
+		l.abandon()
+		return nil, err
+	}
+	return func(status int) {
+		permits.Release(1)
+		l.release(status)
+	}, nil
+}
+
+// Gives up a slot without a request having been made
+func (l *hostLimiter) abandon() {
+	l.Lock()
+	defer l.Unlock()
+	l.active--
+	l.cond.Broadcast()
+}
+
+func (l *hostLimiter) release(status int) {
+	l.Lock()
+	defer l.Unlock()
+	l.active--
+	l.stats.Requests++
+	if status == 0 || status == http.StatusTooManyRequests || status >= http.StatusInternalServerError {
+		l.backOff()
+	} else {
+		l.successes++
+		if l.limit < l.max && l.successes >= l.limit*recoveryFactor {
+			l.limit++
+			l.successes = 0
+		}
+	}
+	l.cond.Broadcast()
+}
+
+// Halves the concurrency allowed to the host. Must be called with the lock held
+func (l *hostLimiter) backOff() {
+	l.stats.Failures++
+	l.successes = 0
+	if l.limit > 1 {
+		l.limit /= 2
+		l.stats.BackOffs++
+	}
+	l.stats.MinConcurrency = min(l.stats.MinConcurrency, l.limit)
+}
+
+// Records a throttling response, pausing every request to the host for as long as it asked
+func (l *hostLimiter) throttled(resp *http.Response) {
+	l.Lock()
+	defer l.Unlock()
+	l.stats.Throttled++
+	// With no wait bounds, only a Retry-After header produces a delay
+	if delay := retryablehttp.DefaultBackoff(0, 0, 0, resp); delay > 0 {
+		until := time.Now().Add(min(delay, maxRetryAfter))
+		if until.After(l.pausedUntil) {
+			l.pausedUntil = until
+		}
+	}
+}
+
+// Used as the client's retry policy, so that throttling seen on any attempt applies to the whole host
+func checkRetry(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+		getLimiter(resp.Request.URL.Hostname()).throttled(resp)
+	}
+	return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+}
+
+// Retry-After is honoured, but capped so a single host can't stall the run
+func backoff(minWait, maxWait time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	return min(retryablehttp.DefaultBackoff(minWait, maxWait, attemptNum, resp), maxRetryAfter)
+}
+
+// Performs a request with the client within the limits of its host
+func doLimited(client *retryablehttp.Client, req *retryablehttp.Request) (*http.Response, error) {
+	release, err := acquireHost(req.Context(), req.URL.Hostname())
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	resp, err := client.Do(req)
+	acct := recordRequest(req.Context(), req.URL.Hostname(), time.Since(start))
+	if err != nil {
+		if resp != nil {
+			resp.Body.Close()
+		}
+		release(0)
+		return nil, err
+	}
+	release(resp.StatusCode)
+	resp.Body = &countingBody{ReadCloser: resp.Body, account: acct}
+	return resp, nil
+}
+
+// Returns the throttling statistics of every host which throttled or failed requests during the run, sorted by host
+func ThrottlingReport() []data.HostThrottling {
+	limitersLock.Lock()
+	defer limitersLock.Unlock()
+	var report []data.HostThrottling
+	for _, l := range limiters {
+		l.Lock()
+		if l.stats.Throttled > 0 || l.stats.Failures > 0 {
+			report = append(report, l.stats)
+		}
+		l.Unlock()
+	}
+	slices.SortFunc(report, func(a, b data.HostThrottling) int {
+		return strings.Compare(a.Host, b.Host)
+	})
+	return report
+}
@@ -0,0 +1,148 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// Memoizes the successful results of calls for the rest of the run. Concurrent calls with the same key share a single call
+type memo[T any] struct {
+	sync.Mutex
+	calls map[string]*memoCall[T]
+	stats MemoStats
+}
+
+type memoCall[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+// How often requests were answered without a network round trip
+type MemoStats struct {
+	Calls int
+	// Calls answered by a request made earlier in the run
+	Hits int
+	// Calls which waited on an identical request already in flight
+	Coalesced int
+}
+
+func (s MemoStats) Misses() int {
+	return s.Calls - s.Hits - s.Coalesced
+}
+
+// Returns the memoized result for the key, calling fn if there is none. leader reports whether fn was called by this caller
+func (m *memo[T]) do(key string, fn func() (T, error)) (value T, err error, leader bool) {
+	m.Lock()
+	m.stats.Calls++
+	if m.calls == nil {
+		m.calls = make(map[string]*memoCall[T])
+	}
+	if call, ok := m.calls[key]; ok {
+		select {
+		case <-call.done:
+			m.stats.Hits++
+		default:
+			m.stats.Coalesced++
+		}
+		m.Unlock()
+		<-call.done
+		return call.value, call.err, false
+	}
+	call := &memoCall[T]{done: make(chan struct{})}
+	m.calls[key] = call
+	m.Unlock()
+
+	call.value, call.err = fn()
+	if call.err != nil {
+		// Callers already waiting share the failure, but later calls may try again
+		m.Lock()
+		delete(m.calls, key)
+		m.Unlock()
+	}
+	close(call.done)
+	return call.value, call.err, true
+}
+
+func (m *memo[T]) Stats() MemoStats {
+	m.Lock()
+	defer m.Unlock()
+	return m.stats
+}
+
+var (
+	responseMemo memo[*memoResponse]
+	probeMemo    memo[*urlInfo]
+)
+
+type memoResponse struct {
+	entry *cacheEntry
+	// Set when the body was too large to share. Only the caller which made the request may use it
+	stream *http.Response
+}
+
+// Fetches a response once per run, sharing the body between every caller that requests the same URL and headers
+func getMemoizedResponse(ctx context.Context, u *url.URL, headers http.Header) (*http.Response, error) {
+	key := cacheKey(u, headers)
+	result, err, leader := responseMemo.do(key, func() (*memoResponse, error) {
+		// Other callers may share the response, so the caller which happens to make the request can't cancel it
+		resp, err := fetchResponse(context.WithoutCancel(ctx), u, headers)
+		if err != nil {
+			return nil, err
+		}
+		return bufferResponse(u, resp)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.stream != nil {
+		if leader {
+			return result.stream, nil
+		}
+		return fetchResponse(ctx, u, headers)
+	}
+	return result.entry.response()
+}
+
+// Reads the body of a response into memory, unless it is too large to keep for the rest of the run
+func bufferResponse(u *url.URL, resp *http.Response) (*memoResponse, error) {
+	if resp.ContentLength > maxCachedBodySize {
+		return &memoResponse{stream: resp}, nil
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxCachedBodySize+1))
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if len(body) > maxCachedBodySize {
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return &memoResponse{stream: resp}, nil
+	}
+	resp.Body.Close()
+	return &memoResponse{entry: &cacheEntry{
+		URL:      u.String(),
+		FinalURL: resp.Request.URL.String(),
+		Header:   resp.Header,
+		body:     body,
+	}}, nil
+}
+
+// Probes a URL once per run, sharing the result between every source that uses it
+func probeMemoized(ctx context.Context, u *url.URL) (*urlInfo, error) {
+	info, err, _ := probeMemo.do(u.String(), func() (*urlInfo, error) {
+		return probeURL(context.WithoutCancel(ctx), u)
+	})
+	return info, err
+}
+
+// Returns statistics for the memoized page requests and URL validations made during the run
+func MemoReport() (pages, validations MemoStats) {
+	return responseMemo.Stats(), probeMemo.Stats()
+}
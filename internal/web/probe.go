@@ -0,0 +1,141 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// What is known about a URL from probing it, without downloading its contents
+type urlInfo struct {
+	// The URL after following redirects
+	FinalURL   *url.URL
+	StatusCode int
+	Status     string
+	// The size of the file, or -1 if the server didn't report it
+	Size         int64
+	LastModified time.Time
+}
+
+type probeMethod int
+
+const (
+	probeHead probeMethod = iota
+	probeRangedGet
+)
+
+var (
+	probeMethodsLock sync.Mutex
+	// Hosts which are known to reject or mishandle HEAD requests
+	probeMethods = make(map[string]probeMethod)
+)
+
+func hostProbeMethod(host string) probeMethod {
+	probeMethodsLock.Lock()
+	defer probeMethodsLock.Unlock()
+	return probeMethods[host]
+}
+
+func setHostProbeMethod(host string, method probeMethod) {
+	probeMethodsLock.Lock()
+	defer probeMethodsLock.Unlock()
+	probeMethods[host] = method
+}
+
+// Checks a URL with a HEAD request, falling back to requesting its first byte for servers which don't handle HEAD.
+// FTP URLs are checked with SIZE instead.
+// Response bodies are never read
+func probeURL(ctx context.Context, u *url.URL) (*urlInfo, error) {
+	if u.Scheme == "ftp" {
+		return probeFTP(ctx, u)
+	}
+	host := u.Hostname()
+	if hostProbeMethod(host) == probeHead {
+		info, err := probe(ctx, u, probeHead)
+		if err == nil && !headRejected(info.StatusCode) {
+			return info, nil
+		}
+		fallback, fallbackErr := probe(ctx, u, probeRangedGet)
+		if fallbackErr != nil {
+			// Report the original failure if neither method could reach the server
+			if err != nil {
+				return nil, err
+			}
+			return nil, fallbackErr
+		}
+		if fallback.StatusCode >= http.StatusOK && fallback.StatusCode < http.StatusMultipleChoices {
+			// The file exists, so the server just can't handle HEAD. Skip straight to the fallback in future
+			setHostProbeMethod(host, probeRangedGet)
+		}
+		return fallback, nil
+	}
+	return probe(ctx, u, probeRangedGet)
+}
+
+// Reports whether a server refused a HEAD request itself, rather than the file, so it should be confirmed with a GET.
+// Other failures such as 404 are trusted, so dead links don't cost a second request
+func headRejected(status int) bool {
+	switch status {
+	case http.StatusForbidden, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return true
+	default:
+		return false
+	}
+}
+
+func probe(ctx context.Context, u *url.URL, method probeMethod) (*urlInfo, error) {
+	httpMethod := http.MethodHead
+	if method == probeRangedGet {
+		httpMethod = http.MethodGet
+	}
+	req, err := retryablehttp.NewRequestWithContext(ctx, httpMethod, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if method == probeRangedGet {
+		req.Header.Set("Range", "bytes=0-0")
+	}
+	resp, err := doLimited(client, req)
+	if err != nil {
+		return nil, err
+	}
+	// Closing without reading abandons the connection, which is far cheaper than downloading an ISO
+	resp.Body.Close()
+
+	info := &urlInfo{
+		FinalURL:   resp.Request.URL,
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Size:       -1,
+	}
+	if resp.StatusCode == http.StatusPartialContent {
+		info.Size = contentRangeSize(resp.Header.Get("Content-Range"))
+		// Report success consistently regardless of the method used
+		info.StatusCode, info.Status = http.StatusOK, "200 OK"
+	} else if resp.ContentLength >= 0 && resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		info.Size = resp.ContentLength
+	}
+	if lastModified, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		info.LastModified = lastModified
+	}
+	return info, nil
+}
+
+// Returns the complete length from a Content-Range header such as "bytes 0-0/1234", or -1 if it is unknown
+func contentRangeSize(header string) int64 {
+	_, total, found := strings.Cut(header, "/")
+	if !found {
+		return -1
+	}
+	size, err := strconv.ParseInt(total, 10, 64)
+	if err != nil {
+		return -1
+	}
+	return size
+}
@@ -0,0 +1,38 @@
+package web
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// Sends a value encoded as JSON with the given method, such as POST to a webhook. Responses are neither cached nor memoized,
+// and failed requests aren't retried, so a webhook which fails after acting on a message doesn't receive it twice
+func SendJSON(method, url string, headers http.Header, body any) error {
+	contents, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := retryablehttp.NewRequest(method, url, contents)
+	if err != nil {
+		return err
+	}
+	req.Header = headers.Clone()
+	if req.Header == nil {
+		req.Header = make(http.Header)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := doLimited(noRetryClient, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// Drain the body so the connection can be reused
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &StatusError{URL: url, StatusCode: resp.StatusCode, Status: resp.Status, Header: resp.Header}
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package web
+
+import (
+	"context"
+	"io"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/quickemu-project/quickget_configs/internal/data"
+)
+
+// The part of an OS's run which requests are attributed to
+type Phase int
+
+const (
+	Scraping Phase = iota
+	Validating
+)
+
+// The OS and phase a context's requests are attributed to
+type account struct {
+	name  string
+	phase Phase
+}
+
+type accountKey struct{}
+
+var (
+	usageLock sync.Mutex
+	// Usage of each OS, with requests which couldn't be attributed to an OS under ""
+	usage     = make(map[string]*data.OSUsage)
+	latencies = make(map[string]*data.HostLatency)
+)
+
+// Returns a context which attributes the requests made with it to a phase of an OS's run
+func WithAccount(ctx context.Context, name string, phase Phase) context.Context {
+	return context.WithValue(ctx, accountKey{}, &account{name: name, phase: phase})
+}
+
+func accountOf(ctx context.Context) *account {
+	acct, _ := ctx.Value(accountKey{}).(*account)
+	return acct
+}
+
+// Returns the usage which requests of the account count towards. Must be called with usageLock held
+func (a *account) usage() *data.NetworkUsage {
+	var name string
+	if a != nil {
+		name = a.name
+	}
+	osUsage := usage[name]
+	if osUsage == nil {
+		osUsage = &data.OSUsage{}
+		usage[name] = osUsage
+	}
+	if a != nil && a.phase == Validating {
+		return &osUsage.Validation
+	}
+	return &osUsage.Scrape
+}
+
+// Counts a request made with the context and the time taken to respond, returning the account its response body counts towards
+func recordRequest(ctx context.Context, host string, elapsed time.Duration) *account {
+	acct := accountOf(ctx)
+	usageLock.Lock()
+	defer usageLock.Unlock()
+	acct.usage().Requests++
+	latency := latencies[host]
+	if latency == nil {
+		latency = &data.HostLatency{Host: host}
+		latencies[host] = latency
+	}
+	latency.Requests++
+	latency.Total += elapsed
+	latency.Max = max(latency.Max, elapsed)
+	return acct
+}
+
+func recordRetry(ctx context.Context) {
+	acct := accountOf(ctx)
+	usageLock.Lock()
+	defer usageLock.Unlock()
+	acct.usage().Retries++
+}
+
+// A response body which counts the bytes read from it
+type countingBody struct {
+	io.ReadCloser
+	account *account
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		usageLock.Lock()
+		b.account.usage().Bytes += int64(n)
+		usageLock.Unlock()
+	}
+	return n, err
+}
+
+// Returns the network usage of each OS, with requests which couldn't be attributed to an OS under "",
+// and the response times of every host, sorted by host
+func UsageReport() (map[string]data.OSUsage, []data.HostLatency) {
+	usageLock.Lock()
+	defer usageLock.Unlock()
+	report := make(map[string]data.OSUsage, len(usage))
+	for name, osUsage := range usage {
+		report[name] = *osUsage
+	}
+	hosts := make([]data.HostLatency, 0, len(latencies))
+	for _, host := range slices.Sorted(maps.Keys(latencies)) {
+		hosts = append(hosts, *latencies[host])
+	}
+	return report, hosts
+}
@@ -7,6 +7,7 @@ import (
 	"log"
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
 	"sync"
 
@@ -14,13 +15,13 @@ import (
 	"github.com/quickemu-project/quickget_configs/pkg/quickgetdata"
 )
 
-func RemoveInvalidConfigs(configs []quickgetdata.Config, errs, csErrs chan<- data.Failure) []quickgetdata.Config {
+func RemoveInvalidConfigs(ctx context.Context, configs []quickgetdata.Config, errs, csErrs chan<- data.Failure) []quickgetdata.Config {
 	var wg sync.WaitGroup
 	ch := make(chan quickgetdata.Config)
 	for _, config := range configs {
 		wg.Go(func() {
 			if !config.Validation.Skip {
-				if err := validateConfigSources(&config); err != nil {
+				if err := validateConfigSources(ctx, &config); err != nil {
 					errs <- data.Failure{
 						Release: config.Release,
 						Edition: config.Edition,
@@ -44,40 +45,45 @@ func RemoveInvalidConfigs(configs []quickgetdata.Config, errs, csErrs chan<- dat
 	return remainingConfigs
 }
 
-func validateConfigSources(config *quickgetdata.Config) error {
+func validateConfigSources(ctx context.Context, config *quickgetdata.Config) error {
 	sources := concatPointers(
 		config.DiskImages,
 		config.ISO,
 		config.IMG,
 		config.FixedISO,
 		config.Floppy,
+		config.KernelBoot,
 	)
-	if err := validateSources(sources, config.Validation); err != nil {
+	if err := validateSources(ctx, sources, config.Validation); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func validateSources(sources iter.Seq[*quickgetdata.Source], validation quickgetdata.Validation) error {
+func validateSources(ctx context.Context, sources iter.Seq[*quickgetdata.Source], validation quickgetdata.Validation) error {
 	var wg sync.WaitGroup
 	errs := make(chan error)
 	for source := range sources {
 		wg.Go(func() {
 			if webSource := source.Web; webSource != nil {
-				filename, err := resolveURLFilename(webSource.URL, validation)
-				if err != nil {
+				if err := validateWebSource(ctx, webSource, validation); err != nil {
 					errs <- err
 				}
-				// We want to add filenames wherever possible to simplify the job of quickget.
-				// Modifying the URL to the redirect is not desired
-				// such redirects could be intended to determine the best available mirror for a location
-				if len(webSource.FileName) == 0 {
-					webSource.FileName = filename
-				}
 			} else if dockerSource := source.Docker; dockerSource != nil {
-				if _, err := resolveURL(dockerSource.URL, validation); err != nil {
+				if _, err := resolveURL(ctx, dockerSource.URL, validation); err != nil {
+					errs <- err
+				}
+			} else if kernelSource := source.Kernel; kernelSource != nil {
+				if err := validateWebSource(ctx, &kernelSource.Kernel, validation); err != nil {
 					errs <- err
+					return
+				}
+				for i := range kernelSource.Initrd {
+					if err := validateWebSource(ctx, &kernelSource.Initrd[i], validation); err != nil {
+						errs <- err
+						return
+					}
 				}
 			}
 		})
@@ -95,44 +101,73 @@ func validateSources(sources iter.Seq[*quickgetdata.Source], validation quickget
 	return nil
 }
 
-func resolveURL(input string, validation quickgetdata.Validation) (*http.Response, error) {
-	url, err := url.Parse(input)
+func validateWebSource(ctx context.Context, webSource *quickgetdata.WebSource, validation quickgetdata.Validation) error {
+	filename, err := resolveURLFilename(ctx, webSource.URL, validation)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	if sem, exists := urlPermits[url.Hostname()]; exists {
-		if err := sem.Acquire(context.Background(), 1); err != nil {
-			return nil, err
+	// We want to add filenames wherever possible to simplify the job of quickget.
+	// Modifying the URL to the redirect is not desired
+	// such redirects could be intended to determine the best available mirror for a location
+	if len(webSource.FileName) == 0 {
+		webSource.FileName = filename
+	}
+	// Mirrors are only alternatives, so an unreachable mirror is dropped rather than failing the source
+	reachable := make([]bool, len(webSource.Mirrors))
+	var wg sync.WaitGroup
+	for i, mirror := range webSource.Mirrors {
+		wg.Go(func() {
+			if _, err := resolveURL(ctx, mirror, validation); err != nil {
+				log.Printf("Warning: Dropping mirror of %s: %s", webSource.URL, err)
+				return
+			}
+			reachable[i] = true
+		})
+	}
+	wg.Wait()
+	// The source may be shared between configs, so it's only written to when a mirror was dropped
+	if slices.Contains(reachable, false) {
+		var mirrors []string
+		for i, mirror := range webSource.Mirrors {
+			if reachable[i] {
+				mirrors = append(mirrors, mirror)
+			}
 		}
-		defer sem.Release(1)
+		webSource.Mirrors = mirrors
 	}
-	if err := permits.Acquire(context.Background(), 1); err != nil {
+	return nil
+}
+
+func resolveURL(ctx context.Context, input string, validation quickgetdata.Validation) (*urlInfo, error) {
+	url, err := url.Parse(input)
+	if err != nil {
 		return nil, err
 	}
-	defer permits.Release(1)
-	resp, err := client.Get(input)
+	info, err := probeMemoized(ctx, url)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-	status := resp.StatusCode
+	status := info.StatusCode
 	if status == http.StatusTooManyRequests {
 		log.Printf("Warning: Got status too many requests for URL %s\n", url)
 	} else if validation.Accept403 && status == http.StatusForbidden {
 		log.Printf("Warning: Got status forbidden for URL %s\n", url)
 	} else if status < http.StatusOK || status >= http.StatusMultipleChoices {
-		return nil, fmt.Errorf("Failed to resolve URL %s: %s", url, resp.Status)
+		return nil, &data.KindError{
+			Kind: data.ValidationRejected,
+			Host: url.Hostname(),
+			Err:  fmt.Errorf("Failed to resolve URL %s: %s", url, info.Status),
+		}
 	}
-	return resp, nil
+	return info, nil
 }
 
-func resolveURLFilename(url string, validation quickgetdata.Validation) (string, error) {
-	resp, err := resolveURL(url, validation)
+func resolveURLFilename(ctx context.Context, url string, validation quickgetdata.Validation) (string, error) {
+	info, err := resolveURL(ctx, url, validation)
 	if err != nil {
 		return "", err
 	}
-	finalUrl := resp.Request.URL
-	fields := strings.Split(finalUrl.Path, "/")
+	fields := strings.Split(info.FinalURL.Path, "/")
 	if len(fields) == 0 {
 		return "", nil
 	}
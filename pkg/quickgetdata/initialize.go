@@ -32,6 +32,16 @@ func NewDockerSource(url string, privileged bool, sharedDirs []string, filename
 	}
 }
 
+func NewKernelSource(kernel WebSource, initrd []WebSource, cmdline string) Source {
+	return Source{
+		Kernel: &KernelSource{
+			Kernel:  kernel,
+			Initrd:  initrd,
+			Cmdline: cmdline,
+		},
+	}
+}
+
 func NewArch(input string) (arch Arch, valid bool) {
 	switch strings.ToLower(input) {
 	case "x86_64", "amd64":
@@ -43,6 +53,21 @@ func NewArch(input string) (arch Arch, valid bool) {
 	case "riscv64":
 		arch = Riscv64
 		valid = true
+	case "i686", "i586", "i486", "i386", "x86", "386":
+		arch = I686
+		valid = true
+	case "armv7", "armv7l", "armv7hl", "armhf", "arm":
+		arch = Armv7
+		valid = true
+	case "ppc64le", "ppc64el", "powerpc64le":
+		arch = Ppc64le
+		valid = true
+	case "s390x":
+		arch = S390x
+		valid = true
+	case "loongarch64", "loong64":
+		arch = Loongarch64
+		valid = true
 	}
 
 	return
@@ -11,11 +11,19 @@ type OSData struct {
 type Arch string
 
 const (
-	X86_64  Arch = "x86_64"
-	Aarch64 Arch = "aarch64"
-	Riscv64 Arch = "riscv64"
+	X86_64      Arch = "x86_64"
+	Aarch64     Arch = "aarch64"
+	Riscv64     Arch = "riscv64"
+	I686        Arch = "i686"
+	Armv7       Arch = "armv7"
+	Ppc64le     Arch = "ppc64le"
+	S390x       Arch = "s390x"
+	Loongarch64 Arch = "loongarch64"
 )
 
+// Architectures that were published before any others were supported. Output restricted to these matches what older consumers expect
+var LegacyArchitectures = []Arch{X86_64, Aarch64, Riscv64}
+
 type GuestOS string
 
 const (
@@ -71,12 +79,76 @@ type Config struct {
 	FixedISO   []Source `json:"fixed_iso,omitempty"`
 	Floppy     []Source `json:"floppy,omitempty"`
 	DiskImages []Disk   `json:"disk_images,omitempty"`
-	TPM        bool     `json:"tpm,omitempty"`
-	RAM        int64    `json:"ram,omitempty"`
+	// Sources which can be booted directly with QEMU's -kernel, -initrd and -append options
+	KernelBoot []Source `json:"kernel_boot,omitempty"`
+	// The disk image is a cloud image which expects a cloud-init (NoCloud) seed to configure users and networking on first boot
+	CloudInit bool `json:"cloud_init,omitempty"`
+	// Recommended virtual hardware. Fields left unset by a provider are filled in from the guest OS defaults
+	Hardware *Hardware `json:"hardware,omitempty"`
+	// Deprecated: Use Hardware.TPM. Kept for existing consumers, set if the config needs any TPM
+	TPM bool `json:"tpm,omitempty"`
+	// Deprecated: Use Hardware.RecommendedRAM. Kept for existing consumers, set to the same value
+	RAM int64 `json:"ram,omitempty"`
 	// This field tells the config generation to modify URL validation logic. This can be done because of ratelimits, datacenter IP blocking, or any other reason
 	Validation Validation `json:"-"`
 }
 
+// Units for memory and disk sizes, which are given in bytes
+const (
+	MiB = 1024 * 1024
+	GiB = 1024 * MiB
+)
+
+type Firmware string
+
+const (
+	BIOS        Firmware = "bios"
+	EFI         Firmware = "efi"
+	AnyFirmware Firmware = "both"
+)
+
+type TPMVersion string
+
+const (
+	TPM1_2 TPMVersion = "1.2"
+	TPM2_0 TPMVersion = "2.0"
+)
+
+type NetworkDevice string
+
+const (
+	VirtioNet NetworkDevice = "virtio-net"
+	E1000     NetworkDevice = "e1000"
+	E1000e    NetworkDevice = "e1000e"
+	RTL8139   NetworkDevice = "rtl8139"
+	PCNet     NetworkDevice = "pcnet"
+	Vmxnet3   NetworkDevice = "vmxnet3"
+)
+
+type DiskBus string
+
+const (
+	VirtioBlk DiskBus = "virtio"
+	SATA      DiskBus = "sata"
+	IDE       DiskBus = "ide"
+	SCSI      DiskBus = "scsi"
+	NVMe      DiskBus = "nvme"
+)
+
+// Virtual hardware a VM should be created with. Memory and disk sizes are in bytes
+type Hardware struct {
+	MinRAM         int64    `json:"min_ram,omitempty"`
+	RecommendedRAM int64    `json:"recommended_ram,omitempty"`
+	CPUs           int      `json:"cpus,omitempty"`
+	DiskSize       int64    `json:"disk_size,omitempty"`
+	Firmware       Firmware `json:"firmware,omitempty"`
+	// Nil if unset, so a provider can turn off Secure Boot where the guest OS default enables it
+	SecureBoot    *bool         `json:"secure_boot,omitempty"`
+	TPM           TPMVersion    `json:"tpm,omitempty"`
+	NetworkDevice NetworkDevice `json:"network_device,omitempty"`
+	DiskBus       DiskBus       `json:"disk_bus,omitempty"`
+}
+
 type Validation struct {
 	Skip      bool
 	Accept403 bool
@@ -93,6 +165,7 @@ type Source struct {
 	FileName string        `json:"file_name,omitempty"`
 	Custom   bool          `json:"custom,omitempty"`
 	Docker   *DockerSource `json:"docker,omitempty"`
+	Kernel   *KernelSource `json:"kernel,omitempty"`
 }
 
 type DockerSource struct {
@@ -102,9 +175,18 @@ type DockerSource struct {
 	OutputFilename string   `json:"output_filename,omitempty"`
 }
 
+type KernelSource struct {
+	Kernel WebSource `json:"kernel"`
+	// Initial ramdisks, in the order they must be concatenated
+	Initrd  []WebSource `json:"initrd,omitempty"`
+	Cmdline string      `json:"cmdline,omitempty"`
+}
+
 type WebSource struct {
 	URL           string        `json:"url"`
 	Checksum      string        `json:"checksum,omitempty"`
 	ArchiveFormat ArchiveFormat `json:"archive_format,omitempty"`
 	FileName      string        `json:"file_name,omitempty"`
+	// Alternative URLs serving the same file, which can be used if the main URL fails
+	Mirrors []string `json:"mirrors,omitempty"`
 }